@@ -6,7 +6,7 @@ toolchain go1.24.4
 
 require (
 	github.com/google/gnostic-models v0.6.9
-	github.com/mark3labs/mcp-go v0.24.1
+	github.com/mark3labs/mcp-go v0.32.0
 	github.com/stretchr/testify v1.10.0
 	k8s.io/api v0.33.0
 	sigs.k8s.io/yaml v1.4.0
@@ -1,33 +1,110 @@
 package main
 
 import (
+	"context"
+	"errors"
+	"flag"
 	"fmt"
 	"os"
+	"os/signal"
+	"syscall"
 
-	"github.com/mark3labs/mcp-go/server"
+	"github.com/kkb0318/kubernetes-mcp/src/auth"
 	"github.com/kkb0318/kubernetes-mcp/src/client"
+	"github.com/kkb0318/kubernetes-mcp/src/config"
 	"github.com/kkb0318/kubernetes-mcp/src/tools"
+	"github.com/kkb0318/kubernetes-mcp/src/transport"
+	"github.com/mark3labs/mcp-go/server"
 )
 
 const Version = "0.1.0"
 
 func main() {
+	cfg, err := config.Load(os.Args[1:], os.LookupEnv)
+	if errors.Is(err, flag.ErrHelp) {
+		os.Exit(0)
+	}
+	if err != nil {
+		fmt.Fprintf(os.Stderr, "Invalid configuration: %v\n", err)
+		os.Exit(1)
+	}
+
 	s := server.NewMCPServer(
 		"MCP k8s Server",
 		Version,
 		server.WithToolCapabilities(false),
 	)
-	
-	multiClient, err := client.NewMultiClusterClient()
+
+	clientOpts := cfg.ClientOptions()
+	clientOpts.UserAgent = "kubernetes-mcp/" + Version
+	multiClient, err := client.NewMultiClusterClient(clientOpts)
 	if err != nil {
 		fmt.Fprintf(os.Stderr, "Error creating MultiCluster client: %v\n", err)
 		os.Exit(1)
 	}
-	
-	tools.RegisterTools(s, multiClient)
 
-	if err := server.ServeStdio(s); err != nil {
+	toolOpts, err := cfg.ToolOptions()
+	if err != nil {
+		fmt.Fprintf(os.Stderr, "Invalid configuration: %v\n", err)
+		os.Exit(1)
+	}
+	if err := tools.RegisterTools(s, multiClient, toolOpts); err != nil {
+		fmt.Fprintf(os.Stderr, "Error registering tools: %v\n", err)
+		os.Exit(1)
+	}
+
+	transportOpts := cfg.TransportOptions()
+	if transportOpts.Transport != transport.Stdio {
+		authn, err := buildAuthenticator(multiClient, cfg)
+		if err != nil {
+			fmt.Fprintf(os.Stderr, "Error configuring authentication: %v\n", err)
+			os.Exit(1)
+		}
+		if authn == nil {
+			fmt.Fprintln(os.Stderr, "Warning: no authentication configured; anyone who can reach the listen address can use the server")
+		}
+		transportOpts.Authenticator = authn
+	}
+
+	ctx, stop := signal.NotifyContext(context.Background(), os.Interrupt, syscall.SIGTERM)
+	defer stop()
+
+	if err := transport.Serve(ctx, s, transportOpts); err != nil {
 		fmt.Fprintf(os.Stderr, "Error starting MCP server: %v\n", err)
 		os.Exit(1)
 	}
 }
+
+// buildAuthenticator combines the enabled authentication methods. It returns nil if none are enabled.
+func buildAuthenticator(multiClient *client.MultiClusterClient, cfg *config.Config) (auth.Authenticator, error) {
+	var authenticators []auth.Authenticator
+
+	if cfg.Transport.ClientCAFile != "" {
+		authenticators = append(authenticators, auth.NewClientCertAuthenticator())
+	}
+
+	if cfg.Auth.TokenFile != "" {
+		staticTokens, err := auth.NewStaticTokenAuthenticatorFromFile(cfg.Auth.TokenFile)
+		if err != nil {
+			return nil, err
+		}
+		authenticators = append(authenticators, staticTokens)
+	}
+
+	if cfg.Auth.TokenReview {
+		c, err := multiClient.GetClient(context.Background(), "")
+		if err != nil {
+			return nil, fmt.Errorf("failed to get client for TokenReview: %w", err)
+		}
+		clientset, err := c.Clientset()
+		if err != nil {
+			return nil, fmt.Errorf("failed to get clientset for TokenReview: %w", err)
+		}
+		authenticators = append(authenticators, auth.NewTokenReviewAuthenticator(clientset.AuthenticationV1().TokenReviews(), cfg.Auth.TokenReviewAudiences))
+	}
+
+	if len(authenticators) == 0 {
+		return nil, nil
+	}
+	return auth.Union(authenticators...), nil
+}
@@ -0,0 +1,93 @@
+package auth
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"net/http"
+	"strings"
+)
+
+// UserInfo describes an authenticated caller.
+type UserInfo struct {
+	Name   string
+	UID    string
+	Groups []string
+	Extra  map[string][]string
+}
+
+// Authenticator verifies the credentials presented with an HTTP request.
+// It returns ok=false with a nil error when the request carries no credentials it understands,
+// so that several authenticators can be combined with Union.
+type Authenticator interface {
+	Authenticate(r *http.Request) (user *UserInfo, ok bool, err error)
+}
+
+// AuthenticatorFunc adapts a function to the Authenticator interface.
+type AuthenticatorFunc func(r *http.Request) (*UserInfo, bool, error)
+
+// Authenticate calls f(r).
+func (f AuthenticatorFunc) Authenticate(r *http.Request) (*UserInfo, bool, error) {
+	return f(r)
+}
+
+// Union returns an Authenticator that tries each authenticator in order and accepts the first success.
+func Union(authenticators ...Authenticator) Authenticator {
+	return AuthenticatorFunc(func(r *http.Request) (*UserInfo, bool, error) {
+		var errs []error
+		for _, a := range authenticators {
+			user, ok, err := a.Authenticate(r)
+			if err != nil {
+				errs = append(errs, err)
+				continue
+			}
+			if ok {
+				return user, true, nil
+			}
+		}
+		return nil, false, errors.Join(errs...)
+	})
+}
+
+type userKey struct{}
+
+// WithUser returns a copy of ctx carrying the authenticated user.
+func WithUser(ctx context.Context, user *UserInfo) context.Context {
+	return context.WithValue(ctx, userKey{}, user)
+}
+
+// UserFrom returns the authenticated user stored in ctx, if any.
+func UserFrom(ctx context.Context) (*UserInfo, bool) {
+	user, ok := ctx.Value(userKey{}).(*UserInfo)
+	return user, ok && user != nil
+}
+
+// Middleware rejects requests that authn cannot authenticate and stores the caller in the request context.
+// Rejected requests never reach next, so no tool handler runs for them.
+func Middleware(authn Authenticator, next http.Handler) http.Handler {
+	return http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		user, ok, err := authn.Authenticate(r)
+		if err != nil || !ok {
+			w.Header().Set("WWW-Authenticate", `Bearer realm="kubernetes-mcp"`)
+			http.Error(w, "Unauthorized", http.StatusUnauthorized)
+			return
+		}
+		next.ServeHTTP(w, r.WithContext(WithUser(r.Context(), user)))
+	})
+}
+
+// bearerToken extracts the token from an "Authorization: Bearer <token>" header.
+func bearerToken(r *http.Request) (string, bool) {
+	header := r.Header.Get("Authorization")
+	scheme, token, found := strings.Cut(header, " ")
+	if !found || !strings.EqualFold(scheme, "Bearer") {
+		return "", false
+	}
+	token = strings.TrimSpace(token)
+	return token, token != ""
+}
+
+// errInvalidToken is returned when a bearer token is presented but not accepted.
+func errInvalidToken(reason string) error {
+	return fmt.Errorf("invalid bearer token: %s", reason)
+}
@@ -0,0 +1,261 @@
+package auth
+
+import (
+	"context"
+	"crypto/ecdsa"
+	"crypto/elliptic"
+	"crypto/rand"
+	"crypto/tls"
+	"crypto/x509"
+	"crypto/x509/pkix"
+	"errors"
+	"math/big"
+	"net/http"
+	"net/http/httptest"
+	"os"
+	"path/filepath"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	authenticationv1 "k8s.io/api/authentication/v1"
+	"k8s.io/apimachinery/pkg/runtime"
+	"k8s.io/client-go/kubernetes/fake"
+	k8stesting "k8s.io/client-go/testing"
+)
+
+func newBearerRequest(token string) *http.Request {
+	req := httptest.NewRequest(http.MethodPost, "/mcp", nil)
+	if token != "" {
+		req.Header.Set("Authorization", "Bearer "+token)
+	}
+	return req
+}
+
+func TestStaticTokenAuthenticatorFromFile(t *testing.T) {
+	path := filepath.Join(t.TempDir(), "tokens.csv")
+	content := "# token,user,uid,groups\nsecret-1,alice,uid-1,\"dev,ops\"\nsecret-2,bob\n"
+	assert.NoError(t, os.WriteFile(path, []byte(content), 0o600))
+
+	authn, err := NewStaticTokenAuthenticatorFromFile(path)
+	assert.NoError(t, err)
+
+	testCases := []struct {
+		name        string
+		token       string
+		expected    *UserInfo
+		expectedOK  bool
+		expectedErr bool
+	}{
+		{
+			name:       "KnownTokenWithGroups",
+			token:      "secret-1",
+			expected:   &UserInfo{Name: "alice", UID: "uid-1", Groups: []string{"dev", "ops"}},
+			expectedOK: true,
+		},
+		{
+			name:       "KnownTokenWithoutGroups",
+			token:      "secret-2",
+			expected:   &UserInfo{Name: "bob"},
+			expectedOK: true,
+		},
+		{
+			name:        "UnknownToken",
+			token:       "nope",
+			expectedErr: true,
+		},
+		{
+			name: "NoToken",
+		},
+	}
+
+	for _, tc := range testCases {
+		t.Run(tc.name, func(t *testing.T) {
+			user, ok, err := authn.Authenticate(newBearerRequest(tc.token))
+			assert.Equal(t, tc.expectedOK, ok)
+			assert.Equal(t, tc.expected, user)
+			if tc.expectedErr {
+				assert.Error(t, err)
+			} else {
+				assert.NoError(t, err)
+			}
+		})
+	}
+}
+
+func TestStaticTokenAuthenticatorFromFile_Invalid(t *testing.T) {
+	path := filepath.Join(t.TempDir(), "tokens.csv")
+	assert.NoError(t, os.WriteFile(path, []byte("only-a-token\n"), 0o600))
+
+	_, err := NewStaticTokenAuthenticatorFromFile(path)
+	assert.Error(t, err)
+}
+
+func TestTokenReviewAuthenticator(t *testing.T) {
+	clientset := fake.NewSimpleClientset()
+	clientset.PrependReactor("create", "tokenreviews", func(action k8stesting.Action) (bool, runtime.Object, error) {
+		review := action.(k8stesting.CreateAction).GetObject().(*authenticationv1.TokenReview)
+		if review.Spec.Token == "sa-token" {
+			review.Status = authenticationv1.TokenReviewStatus{
+				Authenticated: true,
+				User: authenticationv1.UserInfo{
+					Username: "system:serviceaccount:team-a:agent",
+					Groups:   []string{"system:serviceaccounts"},
+					Extra:    map[string]authenticationv1.ExtraValue{"authentication.kubernetes.io/pod-name": {"agent-0"}},
+				},
+			}
+		} else {
+			review.Status = authenticationv1.TokenReviewStatus{Error: "token expired"}
+		}
+		return true, review, nil
+	})
+	authn := NewTokenReviewAuthenticator(clientset.AuthenticationV1().TokenReviews(), nil)
+
+	user, ok, err := authn.Authenticate(newBearerRequest("sa-token"))
+	assert.NoError(t, err)
+	assert.True(t, ok)
+	assert.Equal(t, &UserInfo{
+		Name:   "system:serviceaccount:team-a:agent",
+		Groups: []string{"system:serviceaccounts"},
+		Extra:  map[string][]string{"authentication.kubernetes.io/pod-name": {"agent-0"}},
+	}, user)
+
+	_, ok, err = authn.Authenticate(newBearerRequest("expired"))
+	assert.False(t, ok)
+	assert.ErrorContains(t, err, "token expired")
+}
+
+func TestTokenReviewAuthenticator_CachesReviews(t *testing.T) {
+	reviews := map[string]int{}
+	unavailable := false
+	clientset := fake.NewSimpleClientset()
+	clientset.PrependReactor("create", "tokenreviews", func(action k8stesting.Action) (bool, runtime.Object, error) {
+		review := action.(k8stesting.CreateAction).GetObject().(*authenticationv1.TokenReview)
+		reviews[review.Spec.Token]++
+		if unavailable {
+			return true, nil, errors.New("connection refused")
+		}
+		if review.Spec.Token == "sa-token" {
+			review.Status = authenticationv1.TokenReviewStatus{
+				Authenticated: true,
+				User:          authenticationv1.UserInfo{Username: "system:serviceaccount:team-a:agent"},
+			}
+		} else {
+			review.Status = authenticationv1.TokenReviewStatus{Error: "token expired"}
+		}
+		return true, review, nil
+	})
+	now := time.Date(2025, 1, 1, 0, 0, 0, 0, time.UTC)
+	authn := NewTokenReviewAuthenticator(clientset.AuthenticationV1().TokenReviews(), nil)
+	authn.now = func() time.Time { return now }
+
+	for range 3 {
+		user, ok, err := authn.Authenticate(newBearerRequest("sa-token"))
+		assert.NoError(t, err)
+		assert.True(t, ok)
+		assert.Equal(t, "system:serviceaccount:team-a:agent", user.Name)
+
+		_, ok, err = authn.Authenticate(newBearerRequest("expired"))
+		assert.False(t, ok)
+		assert.ErrorContains(t, err, "token expired")
+	}
+	assert.Equal(t, map[string]int{"sa-token": 1, "expired": 1}, reviews)
+
+	// Rejections expire sooner than accepted tokens.
+	now = now.Add(tokenReviewFailureTTL)
+	_, _, _ = authn.Authenticate(newBearerRequest("sa-token"))
+	_, _, _ = authn.Authenticate(newBearerRequest("expired"))
+	assert.Equal(t, map[string]int{"sa-token": 1, "expired": 2}, reviews)
+
+	now = now.Add(tokenReviewTTL)
+	_, ok, err := authn.Authenticate(newBearerRequest("sa-token"))
+	assert.NoError(t, err)
+	assert.True(t, ok)
+	assert.Equal(t, map[string]int{"sa-token": 2, "expired": 2}, reviews)
+
+	// Failures to reach the API server are not cached.
+	unavailable = true
+	for range 2 {
+		_, ok, err = authn.Authenticate(newBearerRequest("other-token"))
+		assert.False(t, ok)
+		assert.ErrorContains(t, err, "failed to review token")
+	}
+	assert.Equal(t, 2, reviews["other-token"])
+}
+
+func TestClientCertAuthenticator(t *testing.T) {
+	key, err := ecdsa.GenerateKey(elliptic.P256(), rand.Reader)
+	assert.NoError(t, err)
+	template := &x509.Certificate{
+		SerialNumber: big.NewInt(1),
+		Subject:      pkix.Name{CommonName: "carol", Organization: []string{"sre"}},
+		NotBefore:    time.Now(),
+		NotAfter:     time.Now().Add(time.Hour),
+	}
+	der, err := x509.CreateCertificate(rand.Reader, template, template, &key.PublicKey, key)
+	assert.NoError(t, err)
+	cert, err := x509.ParseCertificate(der)
+	assert.NoError(t, err)
+
+	authn := NewClientCertAuthenticator()
+
+	req := httptest.NewRequest(http.MethodPost, "/mcp", nil)
+	_, ok, err := authn.Authenticate(req)
+	assert.False(t, ok)
+	assert.NoError(t, err)
+
+	req.TLS = &tls.ConnectionState{PeerCertificates: []*x509.Certificate{cert}}
+	_, ok, err = authn.Authenticate(req)
+	assert.False(t, ok)
+	assert.Error(t, err)
+
+	req.TLS.VerifiedChains = [][]*x509.Certificate{{cert}}
+	user, ok, err := authn.Authenticate(req)
+	assert.NoError(t, err)
+	assert.True(t, ok)
+	assert.Equal(t, &UserInfo{Name: "carol", Groups: []string{"sre"}}, user)
+}
+
+func TestMiddleware(t *testing.T) {
+	authn := Union(
+		NewClientCertAuthenticator(),
+		NewStaticTokenAuthenticator(map[string]*UserInfo{"good": {Name: "alice"}}),
+	)
+
+	var handled *UserInfo
+	next := http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		handled, _ = UserFrom(r.Context())
+	})
+	handler := Middleware(authn, next)
+
+	testCases := []struct {
+		name         string
+		token        string
+		expectedCode int
+		expectedUser *UserInfo
+	}{
+		{name: "ValidToken", token: "good", expectedCode: http.StatusOK, expectedUser: &UserInfo{Name: "alice"}},
+		{name: "InvalidToken", token: "bad", expectedCode: http.StatusUnauthorized},
+		{name: "NoCredentials", expectedCode: http.StatusUnauthorized},
+	}
+
+	for _, tc := range testCases {
+		t.Run(tc.name, func(t *testing.T) {
+			handled = nil
+			rec := httptest.NewRecorder()
+			handler.ServeHTTP(rec, newBearerRequest(tc.token))
+			assert.Equal(t, tc.expectedCode, rec.Code)
+			assert.Equal(t, tc.expectedUser, handled)
+		})
+	}
+}
+
+func TestUserFrom(t *testing.T) {
+	_, ok := UserFrom(context.Background())
+	assert.False(t, ok)
+
+	user := &UserInfo{Name: "alice"}
+	actual, ok := UserFrom(WithUser(context.Background(), user))
+	assert.True(t, ok)
+	assert.Same(t, user, actual)
+}
@@ -0,0 +1,86 @@
+package auth
+
+import (
+	"crypto/subtle"
+	"encoding/csv"
+	"errors"
+	"fmt"
+	"io"
+	"net/http"
+	"os"
+	"strings"
+)
+
+// StaticTokenAuthenticator accepts a fixed set of bearer tokens.
+type StaticTokenAuthenticator struct {
+	tokens map[string]*UserInfo
+}
+
+// NewStaticTokenAuthenticator creates an authenticator from a token to user mapping.
+func NewStaticTokenAuthenticator(tokens map[string]*UserInfo) *StaticTokenAuthenticator {
+	return &StaticTokenAuthenticator{tokens: tokens}
+}
+
+// NewStaticTokenAuthenticatorFromFile loads tokens from a CSV file in the kube-apiserver
+// --token-auth-file format: token,user,uid,"group1,group2".
+func NewStaticTokenAuthenticatorFromFile(path string) (*StaticTokenAuthenticator, error) {
+	f, err := os.Open(path)
+	if err != nil {
+		return nil, fmt.Errorf("failed to open token file: %w", err)
+	}
+	defer f.Close()
+
+	reader := csv.NewReader(f)
+	reader.FieldsPerRecord = -1
+	reader.TrimLeadingSpace = true
+	reader.Comment = '#'
+
+	tokens := make(map[string]*UserInfo)
+	for line := 1; ; line++ {
+		record, err := reader.Read()
+		if errors.Is(err, io.EOF) {
+			break
+		}
+		if err != nil {
+			return nil, fmt.Errorf("failed to read token file: %w", err)
+		}
+		if len(record) < 2 || record[0] == "" || record[1] == "" {
+			return nil, fmt.Errorf("token file line %d: token and user must be provided", line)
+		}
+		if _, exists := tokens[record[0]]; exists {
+			return nil, fmt.Errorf("token file line %d: duplicate token", line)
+		}
+
+		user := &UserInfo{Name: record[1]}
+		if len(record) > 2 {
+			user.UID = record[2]
+		}
+		if len(record) > 3 && record[3] != "" {
+			for _, group := range strings.Split(record[3], ",") {
+				if group = strings.TrimSpace(group); group != "" {
+					user.Groups = append(user.Groups, group)
+				}
+			}
+		}
+		tokens[record[0]] = user
+	}
+
+	if len(tokens) == 0 {
+		return nil, fmt.Errorf("token file %s contains no tokens", path)
+	}
+	return NewStaticTokenAuthenticator(tokens), nil
+}
+
+// Authenticate checks the request's bearer token against the configured tokens.
+func (a *StaticTokenAuthenticator) Authenticate(r *http.Request) (*UserInfo, bool, error) {
+	token, ok := bearerToken(r)
+	if !ok {
+		return nil, false, nil
+	}
+	for candidate, user := range a.tokens {
+		if subtle.ConstantTimeCompare([]byte(candidate), []byte(token)) == 1 {
+			return user, true, nil
+		}
+	}
+	return nil, false, errInvalidToken("unknown token")
+}
@@ -0,0 +1,159 @@
+package auth
+
+import (
+	"context"
+	"crypto/sha256"
+	"errors"
+	"fmt"
+	"net/http"
+	"sync"
+	"time"
+
+	authenticationv1 "k8s.io/api/authentication/v1"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	authenticationv1client "k8s.io/client-go/kubernetes/typed/authentication/v1"
+)
+
+const (
+	// tokenReviewTTL is how long an accepted token is trusted before it is reviewed again.
+	tokenReviewTTL = 10 * time.Second
+	// tokenReviewFailureTTL is how long a rejected token is refused before it is reviewed again.
+	tokenReviewFailureTTL = 2 * time.Second
+	// maxCachedTokenReviews bounds the number of cached review results.
+	maxCachedTokenReviews = 4096
+)
+
+// TokenReviewAuthenticator validates bearer tokens, such as ServiceAccount tokens,
+// by submitting a TokenReview to the Kubernetes API server.
+// Review results are cached briefly, so that a burst of requests with the same token
+// costs a single review.
+type TokenReviewAuthenticator struct {
+	client    authenticationv1client.TokenReviewInterface
+	audiences []string
+
+	mu    sync.Mutex
+	now   func() time.Time
+	cache map[[sha256.Size]byte]tokenReview
+}
+
+// tokenReview is a cached review result. Results are keyed by the SHA-256 hash of the token,
+// so the tokens themselves are not kept in memory.
+type tokenReview struct {
+	user    *UserInfo
+	err     error
+	expires time.Time
+}
+
+// NewTokenReviewAuthenticator creates an authenticator that asks the API server to review tokens.
+// If audiences is non-empty, tokens must be issued for at least one of them.
+func NewTokenReviewAuthenticator(client authenticationv1client.TokenReviewInterface, audiences []string) *TokenReviewAuthenticator {
+	return &TokenReviewAuthenticator{
+		client:    client,
+		audiences: audiences,
+		now:       time.Now,
+		cache:     make(map[[sha256.Size]byte]tokenReview),
+	}
+}
+
+// Authenticate submits the request's bearer token for review, unless it was reviewed recently.
+func (a *TokenReviewAuthenticator) Authenticate(r *http.Request) (*UserInfo, bool, error) {
+	token, ok := bearerToken(r)
+	if !ok {
+		return nil, false, nil
+	}
+
+	key := sha256.Sum256([]byte(token))
+	if cached, ok := a.lookup(key); ok {
+		return cached.user, cached.user != nil, cached.err
+	}
+
+	user, err := a.review(r.Context(), token)
+	var rejected *tokenRejectedError
+	switch {
+	case errors.As(err, &rejected):
+		err = errInvalidToken(rejected.reason)
+		a.store(key, tokenReview{err: err}, tokenReviewFailureTTL)
+		return nil, false, err
+	case err != nil:
+		// Errors talking to the API server are not cached, so the next request retries.
+		return nil, false, err
+	}
+	a.store(key, tokenReview{user: user}, tokenReviewTTL)
+	return user, true, nil
+}
+
+// tokenRejectedError is returned by review when the API server did not authenticate the token.
+type tokenRejectedError struct {
+	reason string
+}
+
+func (e *tokenRejectedError) Error() string {
+	return e.reason
+}
+
+// review submits token to the API server and returns the user it was issued for.
+func (a *TokenReviewAuthenticator) review(ctx context.Context, token string) (*UserInfo, error) {
+	review := &authenticationv1.TokenReview{
+		Spec: authenticationv1.TokenReviewSpec{
+			Token:     token,
+			Audiences: a.audiences,
+		},
+	}
+	result, err := a.client.Create(ctx, review, metav1.CreateOptions{})
+	if err != nil {
+		return nil, fmt.Errorf("failed to review token: %w", err)
+	}
+	if !result.Status.Authenticated {
+		reason := result.Status.Error
+		if reason == "" {
+			reason = "token was not authenticated"
+		}
+		return nil, &tokenRejectedError{reason: reason}
+	}
+
+	user := &UserInfo{
+		Name:   result.Status.User.Username,
+		UID:    result.Status.User.UID,
+		Groups: result.Status.User.Groups,
+	}
+	if len(result.Status.User.Extra) > 0 {
+		user.Extra = make(map[string][]string, len(result.Status.User.Extra))
+		for key, values := range result.Status.User.Extra {
+			user.Extra[key] = values
+		}
+	}
+	return user, nil
+}
+
+// lookup returns the cached review result for the token hash, if it has not expired.
+func (a *TokenReviewAuthenticator) lookup(key [sha256.Size]byte) (tokenReview, bool) {
+	a.mu.Lock()
+	defer a.mu.Unlock()
+
+	cached, ok := a.cache[key]
+	if !ok || !a.now().Before(cached.expires) {
+		return tokenReview{}, false
+	}
+	return cached, true
+}
+
+// store caches a review result for ttl. Expired results are dropped when the cache is full,
+// and nothing is cached while it is still full afterwards.
+func (a *TokenReviewAuthenticator) store(key [sha256.Size]byte, result tokenReview, ttl time.Duration) {
+	a.mu.Lock()
+	defer a.mu.Unlock()
+
+	now := a.now()
+	if len(a.cache) >= maxCachedTokenReviews {
+		for k, cached := range a.cache {
+			if !now.Before(cached.expires) {
+				delete(a.cache, k)
+			}
+		}
+		if len(a.cache) >= maxCachedTokenReviews {
+			return
+		}
+	}
+	result.expires = now.Add(ttl)
+	a.cache[key] = result
+}
@@ -0,0 +1,51 @@
+package auth
+
+import (
+	"crypto/x509"
+	"errors"
+	"fmt"
+	"net/http"
+	"os"
+)
+
+// ClientCertAuthenticator accepts TLS client certificates that were verified during the handshake.
+// The certificate's common name becomes the user name and its organizations become the groups,
+// following the Kubernetes convention.
+type ClientCertAuthenticator struct{}
+
+// NewClientCertAuthenticator creates an authenticator for verified client certificates.
+func NewClientCertAuthenticator() *ClientCertAuthenticator {
+	return &ClientCertAuthenticator{}
+}
+
+// Authenticate returns the identity of the verified client certificate, if one was presented.
+func (a *ClientCertAuthenticator) Authenticate(r *http.Request) (*UserInfo, bool, error) {
+	if r.TLS == nil || len(r.TLS.PeerCertificates) == 0 {
+		return nil, false, nil
+	}
+	if len(r.TLS.VerifiedChains) == 0 {
+		return nil, false, errors.New("client certificate was not verified")
+	}
+
+	cert := r.TLS.VerifiedChains[0][0]
+	if cert.Subject.CommonName == "" {
+		return nil, false, errors.New("client certificate has no common name")
+	}
+	return &UserInfo{
+		Name:   cert.Subject.CommonName,
+		Groups: cert.Subject.Organization,
+	}, true, nil
+}
+
+// LoadClientCAs reads a PEM bundle of certificate authorities used to verify client certificates.
+func LoadClientCAs(path string) (*x509.CertPool, error) {
+	data, err := os.ReadFile(path)
+	if err != nil {
+		return nil, fmt.Errorf("failed to read client CA file: %w", err)
+	}
+	pool := x509.NewCertPool()
+	if !pool.AppendCertsFromPEM(data) {
+		return nil, fmt.Errorf("no certificates found in client CA file %s", path)
+	}
+	return pool, nil
+}
@@ -0,0 +1,83 @@
+package client
+
+import (
+	"fmt"
+	"sync"
+	"time"
+
+	"k8s.io/client-go/discovery"
+	"k8s.io/client-go/discovery/cached/memory"
+	"k8s.io/client-go/rest"
+	"k8s.io/client-go/restmapper"
+)
+
+// DefaultDiscoveryTTL is how long discovery results are reused before they are fetched again.
+const DefaultDiscoveryTTL = 5 * time.Minute
+
+// discoveryCache holds the discovery results and RESTMapper of one context. It is shared by every
+// client of the context, including impersonating ones, so API resources are discovered once
+// per TTL instead of once per tool call.
+type discoveryCache struct {
+	client discovery.CachedDiscoveryInterface
+	mapper *restmapper.DeferredDiscoveryRESTMapper
+	ttl    time.Duration
+	now    func() time.Time
+
+	mu      sync.Mutex
+	expires time.Time
+}
+
+func newDiscoveryCache(config *rest.Config, ttl time.Duration) (*discoveryCache, error) {
+	disco, err := discovery.NewDiscoveryClientForConfig(config)
+	if err != nil {
+		return nil, fmt.Errorf("failed to create discovery client: %w", err)
+	}
+	if ttl <= 0 {
+		ttl = DefaultDiscoveryTTL
+	}
+	cached := memory.NewMemCacheClient(disco)
+	return &discoveryCache{
+		client: cached,
+		mapper: restmapper.NewDeferredDiscoveryRESTMapper(cached),
+		ttl:    ttl,
+		now:    time.Now,
+	}, nil
+}
+
+// expire drops the cached results once they are older than the TTL.
+func (c *discoveryCache) expire() {
+	c.mu.Lock()
+	defer c.mu.Unlock()
+
+	now := c.now()
+	if !c.expires.IsZero() && now.After(c.expires) {
+		// Resetting the mapper also invalidates the discovery client it wraps
+		c.mapper.Reset()
+		c.expires = time.Time{}
+	}
+	if c.expires.IsZero() {
+		c.expires = now.Add(c.ttl)
+	}
+}
+
+// invalidate drops the cached results, for example when a kind is not found because a CRD was
+// just installed, and restarts the TTL.
+func (c *discoveryCache) invalidate() {
+	c.mu.Lock()
+	defer c.mu.Unlock()
+
+	c.mapper.Reset()
+	c.expires = c.now().Add(c.ttl)
+}
+
+// discoveryClient returns the shared cached discovery client.
+func (c *discoveryCache) discoveryClient() discovery.CachedDiscoveryInterface {
+	c.expire()
+	return c.client
+}
+
+// restMapper returns the shared RESTMapper backed by the cached discovery client.
+func (c *discoveryCache) restMapper() *restmapper.DeferredDiscoveryRESTMapper {
+	c.expire()
+	return c.mapper
+}
@@ -0,0 +1,150 @@
+package client
+
+import (
+	"context"
+	"net/http"
+	"net/http/httptest"
+	"sync/atomic"
+	"testing"
+	"time"
+
+	"github.com/kkb0318/kubernetes-mcp/src/auth"
+	"github.com/stretchr/testify/assert"
+	"k8s.io/apimachinery/pkg/runtime/schema"
+	"k8s.io/client-go/rest"
+)
+
+// newDiscoveryServer serves the core API group with pods and counts requests for its resources.
+func newDiscoveryServer(t *testing.T) (*httptest.Server, *atomic.Int32) {
+	t.Helper()
+	var requests atomic.Int32
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		w.Header().Set("Content-Type", "application/json")
+		switch r.URL.Path {
+		case "/api":
+			_, _ = w.Write([]byte(`{"kind":"APIVersions","versions":["v1"]}`))
+		case "/apis":
+			_, _ = w.Write([]byte(`{"kind":"APIGroupList","groups":[]}`))
+		case "/api/v1":
+			requests.Add(1)
+			_, _ = w.Write([]byte(`{"kind":"APIResourceList","groupVersion":"v1","resources":[{"name":"pods","kind":"Pod","namespaced":true,"verbs":["list"]}]}`))
+		default:
+			http.NotFound(w, r)
+		}
+	}))
+	t.Cleanup(server.Close)
+	return server, &requests
+}
+
+func TestKubernetesClient_DiscoveryCache(t *testing.T) {
+	server, requests := newDiscoveryServer(t)
+
+	k, err := newKubernetesClient(&rest.Config{Host: server.URL}, time.Minute)
+	assert.NoError(t, err)
+	now := time.Now()
+	k.discovery.now = func() time.Time { return now }
+
+	discover := func(client *KubernetesClient) {
+		t.Helper()
+		disco, err := client.DiscoClient()
+		assert.NoError(t, err)
+		resources, err := disco.ServerPreferredResources()
+		assert.NoError(t, err)
+		assert.Len(t, resources, 1)
+	}
+
+	discover(k)
+	discover(k)
+	assert.Equal(t, int32(1), requests.Load())
+
+	// Impersonating clients of the same context share the cache
+	discover(k.impersonating(&auth.UserInfo{Name: "alice"}))
+	assert.Equal(t, int32(1), requests.Load())
+
+	// The RESTMapper uses the same cache
+	mapper, err := k.RESTMapper()
+	assert.NoError(t, err)
+	gvk, err := mapper.KindFor(schemaResource("pods"))
+	assert.NoError(t, err)
+	assert.Equal(t, "Pod", gvk.Kind)
+	assert.Equal(t, int32(1), requests.Load())
+
+	// Results are fetched again once the TTL has expired
+	now = now.Add(2 * time.Minute)
+	discover(k)
+	assert.Equal(t, int32(2), requests.Load())
+	discover(k)
+	assert.Equal(t, int32(2), requests.Load())
+}
+
+func TestKubernetesClient_InvalidateDiscovery(t *testing.T) {
+	var installed atomic.Bool
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		w.Header().Set("Content-Type", "application/json")
+		switch r.URL.Path {
+		case "/api":
+			_, _ = w.Write([]byte(`{"kind":"APIVersions","versions":["v1"]}`))
+		case "/api/v1":
+			_, _ = w.Write([]byte(`{"kind":"APIResourceList","groupVersion":"v1","resources":[{"name":"pods","kind":"Pod","namespaced":true,"verbs":["list"]}]}`))
+		case "/apis":
+			if !installed.Load() {
+				_, _ = w.Write([]byte(`{"kind":"APIGroupList","groups":[]}`))
+				return
+			}
+			_, _ = w.Write([]byte(`{"kind":"APIGroupList","groups":[{"name":"example.com","versions":[{"groupVersion":"example.com/v1","version":"v1"}],"preferredVersion":{"groupVersion":"example.com/v1","version":"v1"}}]}`))
+		case "/apis/example.com/v1":
+			_, _ = w.Write([]byte(`{"kind":"APIResourceList","groupVersion":"example.com/v1","resources":[{"name":"widgets","kind":"Widget","namespaced":true,"verbs":["list"]}]}`))
+		default:
+			http.NotFound(w, r)
+		}
+	}))
+	t.Cleanup(server.Close)
+
+	k, err := newKubernetesClient(&rest.Config{Host: server.URL}, time.Minute)
+	assert.NoError(t, err)
+	now := time.Now()
+	k.discovery.now = func() time.Time { return now }
+	widgets := schema.GroupVersionResource{Group: "example.com", Resource: "widgets"}
+
+	mapper, err := k.RESTMapper()
+	assert.NoError(t, err)
+	_, err = mapper.KindFor(widgets)
+	assert.Error(t, err)
+
+	// The CRD is installed, but the mapper serves its cached mappings until invalidated
+	installed.Store(true)
+	_, err = mapper.KindFor(widgets)
+	assert.Error(t, err)
+
+	now = now.Add(30 * time.Second)
+	k.InvalidateDiscovery()
+	gvk, err := mapper.KindFor(widgets)
+	assert.NoError(t, err)
+	assert.Equal(t, "Widget", gvk.Kind)
+
+	// Invalidating restarts the TTL
+	assert.Equal(t, now.Add(time.Minute), k.discovery.expires)
+}
+
+func TestMultiClusterClient_DiscoveryCachePerContext(t *testing.T) {
+	stubNotInCluster(t)
+	t.Setenv("KUBECONFIG", writeKubeconfig(t, "config", testKubeconfig))
+
+	m, err := NewMultiClusterClient(Options{Impersonate: true, DiscoveryTTL: time.Hour})
+	assert.NoError(t, err)
+
+	dev, err := m.GetClient(context.Background(), "dev")
+	assert.NoError(t, err)
+	alice, err := m.GetClient(auth.WithUser(context.Background(), &auth.UserInfo{Name: "alice"}), "dev")
+	assert.NoError(t, err)
+	prod, err := m.GetClient(context.Background(), "prod")
+	assert.NoError(t, err)
+
+	assert.Same(t, dev.(*ClientWrapper).client.discovery, alice.(*ClientWrapper).client.discovery)
+	assert.NotSame(t, dev.(*ClientWrapper).client.discovery, prod.(*ClientWrapper).client.discovery)
+	assert.Equal(t, time.Hour, dev.(*ClientWrapper).client.discovery.ttl)
+}
+
+func schemaResource(resource string) schema.GroupVersionResource {
+	return schema.GroupVersionResource{Resource: resource}
+}
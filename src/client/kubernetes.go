@@ -2,64 +2,205 @@ package client
 
 import (
 	"fmt"
-	"os"
-	"path/filepath"
+	"net/http"
+	"sync"
+	"time"
 
+	"github.com/kkb0318/kubernetes-mcp/src/auth"
 	"k8s.io/apimachinery/pkg/api/meta"
 	"k8s.io/apimachinery/pkg/runtime/schema"
 	"k8s.io/client-go/discovery"
-	"k8s.io/client-go/discovery/cached/memory"
 	"k8s.io/client-go/dynamic"
 	"k8s.io/client-go/kubernetes"
+	"k8s.io/client-go/kubernetes/scheme"
 	"k8s.io/client-go/rest"
-	"k8s.io/client-go/restmapper"
 	"k8s.io/client-go/tools/clientcmd"
+	"k8s.io/client-go/transport"
+	"k8s.io/client-go/util/flowcontrol"
 )
 
 type KubernetesClient struct {
-	config *rest.Config
+	config    *rest.Config
+	discovery *discoveryCache
+	// base is the client an impersonating client was derived from, whose connections it shares
+	base *KubernetesClient
+
+	// The API clients are created on first use and reused for every later call,
+	// sharing one HTTP client so connections are kept alive between tool calls.
+	mu         sync.Mutex
+	httpClient *http.Client
+	dynamic    dynamic.Interface
+	clientset  *kubernetes.Clientset
+	restClient *rest.RESTClient
+	// rateLimiter is shared by the impersonating clients derived from this one
+	rateLimiter flowcontrol.RateLimiter
+}
+
+// newKubernetesClient creates a client for config whose discovery results are cached for discoveryTTL.
+func newKubernetesClient(config *rest.Config, discoveryTTL time.Duration) (*KubernetesClient, error) {
+	cache, err := newDiscoveryCache(config, discoveryTTL)
+	if err != nil {
+		return nil, err
+	}
+	return &KubernetesClient{config: config, discovery: cache}, nil
 }
 
 func NewKubernetesClient() (*KubernetesClient, error) {
 	config, err := rest.InClusterConfig()
 	if err != nil {
-		// fallback to kubeconfig
-		var kubeconfig string
-		if kubeconfigEnv := os.Getenv("KUBECONFIG"); kubeconfigEnv != "" {
-			kubeconfig = kubeconfigEnv
-		} else {
-			// Try multiple ways to find home directory
-			home := os.Getenv("HOME")
-			if home == "" {
-				if userHome, err := os.UserHomeDir(); err == nil {
-					home = userHome
-				}
-			}
-			kubeconfig = filepath.Join(home, ".kube", "config")
-		}
-		config, err = clientcmd.BuildConfigFromFlags("", kubeconfig)
+		// fallback to kubeconfig, honoring KUBECONFIG lists and ~/.kube/config like kubectl does
+		config, err = clientcmd.NewNonInteractiveDeferredLoadingClientConfig(
+			clientcmd.NewDefaultClientConfigLoadingRules(),
+			&clientcmd.ConfigOverrides{},
+		).ClientConfig()
 		if err != nil {
 			return nil, fmt.Errorf("failed to load kubeconfig: %w", err)
 		}
 	}
-	return &KubernetesClient{config: config}, nil
+	return newKubernetesClient(config, DefaultDiscoveryTTL)
+}
+
+// impersonating returns a copy of the client that impersonates the given user. The copy is cheap
+// to create, so one is made per request instead of being cached per caller: it sends its requests
+// through the connections of k with impersonation headers added, shares the rate limit of every
+// other copy and shares the discovery cache, since API discovery does not depend on the caller.
+func (k *KubernetesClient) impersonating(user *auth.UserInfo) *KubernetesClient {
+	config := rest.CopyConfig(k.config)
+	config.Impersonate = rest.ImpersonationConfig{
+		UserName: user.Name,
+		UID:      user.UID,
+		Groups:   user.Groups,
+		Extra:    user.Extra,
+	}
+	config.RateLimiter = k.impersonationRateLimiter()
+	return &KubernetesClient{config: config, discovery: k.discovery, base: k}
+}
+
+// impersonationRateLimiter returns the rate limiter shared by the impersonating copies of the
+// client, or nil if the client has no QPS limit configured.
+func (k *KubernetesClient) impersonationRateLimiter() flowcontrol.RateLimiter {
+	k.mu.Lock()
+	defer k.mu.Unlock()
+
+	if k.rateLimiter == nil && k.config.RateLimiter == nil && k.config.QPS > 0 {
+		k.rateLimiter = flowcontrol.NewTokenBucketRateLimiter(k.config.QPS, k.config.Burst)
+	}
+	return k.rateLimiter
+}
+
+// sharedHTTPClient returns the HTTP client shared by the API clients.
+func (k *KubernetesClient) sharedHTTPClient() (*http.Client, error) {
+	k.mu.Lock()
+	defer k.mu.Unlock()
+	return k.httpClientLocked()
+}
+
+// httpClientLocked returns the HTTP client shared by the API clients. k.mu must be held.
+// An impersonating client wraps the transport of its base client.
+func (k *KubernetesClient) httpClientLocked() (*http.Client, error) {
+	if k.httpClient != nil {
+		return k.httpClient, nil
+	}
+	if k.base == nil {
+		httpClient, err := rest.HTTPClientFor(k.config)
+		if err != nil {
+			return nil, fmt.Errorf("failed to create HTTP client: %w", err)
+		}
+		k.httpClient = httpClient
+		return k.httpClient, nil
+	}
+
+	base, err := k.base.sharedHTTPClient()
+	if err != nil {
+		return nil, err
+	}
+	impersonate := k.config.Impersonate
+	k.httpClient = &http.Client{
+		Transport: transport.NewImpersonatingRoundTripper(transport.ImpersonationConfig{
+			UserName: impersonate.UserName,
+			UID:      impersonate.UID,
+			Groups:   impersonate.Groups,
+			Extra:    impersonate.Extra,
+		}, base.Transport),
+		Timeout: base.Timeout,
+	}
+	return k.httpClient, nil
 }
 
+// DynamicClient returns the client's dynamic client, creating it on first use.
 func (k *KubernetesClient) DynamicClient() (dynamic.Interface, error) {
-	return dynamic.NewForConfig(k.config)
+	k.mu.Lock()
+	defer k.mu.Unlock()
+
+	if k.dynamic == nil {
+		httpClient, err := k.httpClientLocked()
+		if err != nil {
+			return nil, err
+		}
+		dynClient, err := dynamic.NewForConfigAndClient(k.config, httpClient)
+		if err != nil {
+			return nil, err
+		}
+		k.dynamic = dynClient
+	}
+	return k.dynamic, nil
 }
+
+// DiscoClient returns the context's cached discovery client. Results are reused until the discovery
+// TTL expires or the client is invalidated.
 func (k *KubernetesClient) DiscoClient() (discovery.DiscoveryInterface, error) {
-	return discovery.NewDiscoveryClientForConfig(k.config)
+	return k.discovery.discoveryClient(), nil
 }
+
+// InvalidateDiscovery drops the context's cached discovery results and RESTMapper mappings.
+func (k *KubernetesClient) InvalidateDiscovery() {
+	k.discovery.invalidate()
+}
+
+// Clientset returns the client's typed clientset, creating it on first use.
 func (k *KubernetesClient) Clientset() (*kubernetes.Clientset, error) {
-	return kubernetes.NewForConfig(k.config)
+	k.mu.Lock()
+	defer k.mu.Unlock()
+
+	if k.clientset == nil {
+		httpClient, err := k.httpClientLocked()
+		if err != nil {
+			return nil, err
+		}
+		clientset, err := kubernetes.NewForConfigAndClient(k.config, httpClient)
+		if err != nil {
+			return nil, err
+		}
+		k.clientset = clientset
+	}
+	return k.clientset, nil
 }
-func (k *KubernetesClient) RESTMapper() (meta.RESTMapper, error) {
-	disco, err := k.DiscoClient()
-	if err != nil {
-		return nil, fmt.Errorf("failed to create discovery client: %w", err)
+
+// RESTClient returns the client's unversioned REST client, creating it on first use.
+// Requests are expected to set their full path with AbsPath.
+func (k *KubernetesClient) RESTClient() (rest.Interface, error) {
+	k.mu.Lock()
+	defer k.mu.Unlock()
+
+	if k.restClient == nil {
+		httpClient, err := k.httpClientLocked()
+		if err != nil {
+			return nil, err
+		}
+		config := rest.CopyConfig(k.config)
+		config.NegotiatedSerializer = scheme.Codecs.WithoutConversion()
+		restClient, err := rest.UnversionedRESTClientForConfigAndClient(config, httpClient)
+		if err != nil {
+			return nil, err
+		}
+		k.restClient = restClient
 	}
-	return restmapper.NewDeferredDiscoveryRESTMapper(memory.NewMemCacheClient(disco)), nil
+	return k.restClient, nil
+}
+
+// RESTMapper returns the context's RESTMapper, backed by the cached discovery client.
+func (k *KubernetesClient) RESTMapper() (meta.RESTMapper, error) {
+	return k.discovery.restMapper(), nil
 }
 
 func (k *KubernetesClient) ResourceInterface(
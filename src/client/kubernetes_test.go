@@ -0,0 +1,79 @@
+package client
+
+import (
+	"context"
+	"testing"
+
+	"github.com/kkb0318/kubernetes-mcp/src/auth"
+	"github.com/stretchr/testify/assert"
+	"k8s.io/apimachinery/pkg/runtime/schema"
+	utilnet "k8s.io/apimachinery/pkg/util/net"
+	"k8s.io/client-go/rest"
+)
+
+func TestKubernetesClient_ReusesClients(t *testing.T) {
+	k, err := newKubernetesClient(&rest.Config{Host: "https://example.invalid"}, 0)
+	assert.NoError(t, err)
+
+	dyn, err := k.DynamicClient()
+	assert.NoError(t, err)
+	again, err := k.DynamicClient()
+	assert.NoError(t, err)
+	assert.Same(t, dyn, again)
+
+	clientset, err := k.Clientset()
+	assert.NoError(t, err)
+	againClientset, err := k.Clientset()
+	assert.NoError(t, err)
+	assert.Same(t, clientset, againClientset)
+	assert.Same(t, k.httpClient, clientset.CoreV1().RESTClient().(*rest.RESTClient).Client)
+
+	restClient, err := k.RESTClient()
+	assert.NoError(t, err)
+	againRESTClient, err := k.RESTClient()
+	assert.NoError(t, err)
+	assert.Same(t, restClient, againRESTClient)
+
+	_, err = k.ResourceInterface(schema.GroupVersionResource{Version: "v1", Resource: "pods"}, true, "default")
+	assert.NoError(t, err)
+	assert.Same(t, dyn, k.dynamic)
+
+	// Impersonating clients add the impersonation headers on top of the base client's transport
+	impersonated := k.impersonating(&auth.UserInfo{Name: "alice"})
+	impersonatedDyn, err := impersonated.DynamicClient()
+	assert.NoError(t, err)
+	assert.NotSame(t, dyn, impersonatedDyn)
+	wrapper, ok := impersonated.httpClient.Transport.(utilnet.RoundTripperWrapper)
+	if assert.True(t, ok) {
+		assert.Equal(t, k.httpClient.Transport, wrapper.WrappedRoundTripper())
+	}
+}
+
+func TestMultiClusterClient_RequestSettings(t *testing.T) {
+	stubNotInCluster(t)
+	t.Setenv("KUBECONFIG", writeKubeconfig(t, "config", testKubeconfig))
+
+	m, err := NewMultiClusterClient(Options{QPS: 50, Burst: 100, UserAgent: "kubernetes-mcp/test", Impersonate: true})
+	assert.NoError(t, err)
+
+	c, err := m.GetClient(auth.WithUser(context.Background(), &auth.UserInfo{Name: "alice"}), "dev")
+	assert.NoError(t, err)
+	config := c.(*ClientWrapper).client.config
+	assert.Equal(t, float32(50), config.QPS)
+	assert.Equal(t, 100, config.Burst)
+	assert.Equal(t, "kubernetes-mcp/test", config.UserAgent)
+
+	// The per-request impersonating clients of a context share one rate limiter
+	other, err := m.GetClient(auth.WithUser(context.Background(), &auth.UserInfo{Name: "bob"}), "dev")
+	assert.NoError(t, err)
+	assert.NotNil(t, config.RateLimiter)
+	assert.Same(t, config.RateLimiter, other.(*ClientWrapper).client.config.RateLimiter)
+
+	m, err = NewMultiClusterClient(Options{})
+	assert.NoError(t, err)
+	c, err = m.GetClient(context.Background(), "dev")
+	assert.NoError(t, err)
+	config = c.(*ClientWrapper).client.config
+	assert.Zero(t, config.QPS)
+	assert.Empty(t, config.UserAgent)
+}
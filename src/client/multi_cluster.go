@@ -1,11 +1,15 @@
 package client
 
 import (
+	"context"
 	"fmt"
-	"os"
 	"path/filepath"
+	"sort"
 	"sync"
+	"time"
 
+	"github.com/kkb0318/kubernetes-mcp/src/auth"
+	"github.com/kkb0318/kubernetes-mcp/src/policy"
 	"github.com/kkb0318/kubernetes-mcp/src/tools"
 	"k8s.io/apimachinery/pkg/api/meta"
 	"k8s.io/apimachinery/pkg/runtime/schema"
@@ -16,55 +20,144 @@ import (
 	"k8s.io/client-go/tools/clientcmd"
 )
 
+// InClusterContext is the pseudo-context name for the cluster the server runs in,
+// authenticated with the pod's ServiceAccount.
+const InClusterContext = "in-cluster"
+
+// inClusterSource is reported as the origin of the in-cluster pseudo-context.
+const inClusterSource = "/var/run/secrets/kubernetes.io/serviceaccount"
+
+// inClusterConfig loads the in-cluster configuration; tests replace it to simulate running in a pod.
+var inClusterConfig = rest.InClusterConfig
+
+// Options configures a MultiClusterClient.
+type Options struct {
+	// Kubeconfig overrides the KUBECONFIG environment variable. It may list several files
+	// separated by the OS path list separator.
+	Kubeconfig string
+	// DefaultContext overrides the current context from the kubeconfig.
+	DefaultContext string
+	// AllowedContexts restricts the contexts that can be used to those matching one of these
+	// glob or "re:" regular expression patterns. Empty means all contexts.
+	AllowedContexts []string
+	// DeniedContexts blocks contexts matching any of these patterns, even if they are allowed.
+	DeniedContexts []string
+	// DiscoveryTTL is how long discovery results are cached per context. Zero uses DefaultDiscoveryTTL.
+	DiscoveryTTL time.Duration
+	// QPS and Burst limit the requests sent to each cluster. Zero uses the client-go defaults.
+	QPS   float32
+	Burst int
+	// UserAgent is sent with every request. Empty uses the client-go default.
+	UserAgent string
+	// Impersonate makes every request act as the authenticated caller found in the request context
+	// instead of the identity from the kubeconfig or pod ServiceAccount.
+	Impersonate bool
+	// ImpersonateExtras lists the extra attributes of the caller that are forwarded when impersonating.
+	// Other extras, such as the per-token authentication.kubernetes.io/credential-id of
+	// ServiceAccount tokens, are dropped.
+	ImpersonateExtras []string
+}
+
 // MultiClusterClient manages connections to multiple Kubernetes clusters using contexts.
 type MultiClusterClient struct {
-	clients        map[string]*KubernetesClient
-	defaultContext string
-	kubeconfig     string
-	mu             sync.RWMutex
+	clients           map[string]*KubernetesClient
+	defaultContext    string
+	loadingRules      *clientcmd.ClientConfigLoadingRules
+	inCluster         bool
+	contextFilter     *policy.Filter
+	impersonate       bool
+	impersonateExtras []string
+	discoveryTTL      time.Duration
+	qps               float32
+	burst             int
+	userAgent         string
+	mu                sync.RWMutex
 }
 
 // NewMultiClusterClient creates a new MultiClusterClient that can manage multiple cluster connections.
-func NewMultiClusterClient() (*MultiClusterClient, error) {
-	var kubeconfig string
-	if kubeconfigEnv := os.Getenv("KUBECONFIG"); kubeconfigEnv != "" {
-		kubeconfig = kubeconfigEnv
-	} else {
-		// Try multiple ways to find home directory
-		home := os.Getenv("HOME")
-		if home == "" {
-			if userHome, err := os.UserHomeDir(); err == nil {
-				home = userHome
-			}
-		}
-		kubeconfig = filepath.Join(home, ".kube", "config")
+// Kubeconfig files are located with the standard kubectl precedence: every file listed in the
+// KUBECONFIG environment variable (merged in order), or ~/.kube/config when it is unset.
+// When running inside a pod, the local cluster is additionally available as the "in-cluster" context.
+func NewMultiClusterClient(opts Options) (*MultiClusterClient, error) {
+	contextFilter, err := policy.NewFilter(opts.AllowedContexts, opts.DeniedContexts)
+	if err != nil {
+		return nil, fmt.Errorf("invalid context policy: %w", err)
 	}
 
-	// Get the default context from kubeconfig
-	defaultContext, err := getDefaultContext(kubeconfig)
-	if err != nil {
-		return nil, fmt.Errorf("failed to get default context: %w", err)
+	loadingRules := clientcmd.NewDefaultClientConfigLoadingRules()
+	if opts.Kubeconfig != "" {
+		loadingRules.Precedence = filepath.SplitList(opts.Kubeconfig)
+	}
+	_, err = inClusterConfig()
+	inCluster := err == nil
+
+	defaultContext := opts.DefaultContext
+	if defaultContext == "" {
+		// Get the default context from the merged kubeconfig
+		defaultContext, err = getDefaultContext(loadingRules)
+		if err != nil {
+			if !inCluster {
+				return nil, fmt.Errorf("failed to get default context: %w", err)
+			}
+			// Without a usable kubeconfig, default to the cluster the server runs in
+			defaultContext = InClusterContext
+		}
 	}
 
 	return &MultiClusterClient{
-		clients:        make(map[string]*KubernetesClient),
-		defaultContext: defaultContext,
-		kubeconfig:     kubeconfig,
+		clients:           make(map[string]*KubernetesClient),
+		defaultContext:    defaultContext,
+		loadingRules:      loadingRules,
+		inCluster:         inCluster,
+		contextFilter:     contextFilter,
+		impersonate:       opts.Impersonate,
+		impersonateExtras: opts.ImpersonateExtras,
+		discoveryTTL:      opts.DiscoveryTTL,
+		qps:               opts.QPS,
+		burst:             opts.Burst,
+		userAgent:         opts.UserAgent,
 	}, nil
 }
 
 // GetClient returns a Kubernetes client for the specified context.
 // If context is empty, it uses the default context.
-// Clients are cached to avoid recreating connections.
-func (m *MultiClusterClient) GetClient(context string) (tools.Client, error) {
-	if context == "" {
-		context = m.defaultContext
+// When impersonation is enabled and ctx carries an authenticated caller, the client impersonates
+// that caller so requests are subject to the caller's RBAC.
+// Clients are cached per context and identity to avoid recreating connections.
+func (m *MultiClusterClient) GetClient(ctx context.Context, contextName string) (tools.Client, error) {
+	if contextName == "" {
+		contextName = m.defaultContext
+	}
+	if err := m.contextFilter.Check(contextName); err != nil {
+		return nil, fmt.Errorf("context '%s' is blocked by the server's context policy: %w", contextName, err)
+	}
+
+	var user *auth.UserInfo
+	if m.impersonate {
+		if caller, ok := auth.UserFrom(ctx); ok {
+			user = m.impersonatedUser(caller)
+		}
+	}
+	base, err := m.baseClient(contextName)
+	if err != nil {
+		return nil, err
 	}
 
+	// Impersonating clients are built per request on top of the base client's connections
+	// rather than cached, so the number of clients does not grow with the number of callers.
+	client := base
+	if user != nil {
+		client = base.impersonating(user)
+	}
+	return &ClientWrapper{client: client, context: contextName}, nil
+}
+
+// baseClient returns the cached client for the given context, acting as the server's own identity.
+func (m *MultiClusterClient) baseClient(contextName string) (*KubernetesClient, error) {
 	m.mu.RLock()
-	if client, exists := m.clients[context]; exists {
+	if client, exists := m.clients[contextName]; exists {
 		m.mu.RUnlock()
-		return &ClientWrapper{client: client, context: context}, nil
+		return client, nil
 	}
 	m.mu.RUnlock()
 
@@ -73,29 +166,47 @@ func (m *MultiClusterClient) GetClient(context string) (tools.Client, error) {
 	defer m.mu.Unlock()
 
 	// Double-check in case another goroutine created it while we were waiting for the lock
-	if client, exists := m.clients[context]; exists {
-		return &ClientWrapper{client: client, context: context}, nil
+	if client, exists := m.clients[contextName]; exists {
+		return client, nil
 	}
 
-	client, err := m.createClientForContext(context)
+	client, err := m.createClientForContext(contextName)
 	if err != nil {
-		return nil, fmt.Errorf("failed to create client for context '%s': %w", context, err)
+		return nil, fmt.Errorf("failed to create client for context '%s': %w", contextName, err)
 	}
+	m.clients[contextName] = client
+	return client, nil
+}
 
-	m.clients[context] = client
-	return &ClientWrapper{client: client, context: context}, nil
+// impersonatedUser returns the identity to impersonate for caller, with only the allowed extras.
+// Extras such as per-token credential IDs are not forwarded.
+func (m *MultiClusterClient) impersonatedUser(caller *auth.UserInfo) *auth.UserInfo {
+	user := &auth.UserInfo{Name: caller.Name, UID: caller.UID, Groups: caller.Groups}
+	for _, key := range m.impersonateExtras {
+		if values, ok := caller.Extra[key]; ok {
+			if user.Extra == nil {
+				user.Extra = map[string][]string{}
+			}
+			user.Extra[key] = values
+		}
+	}
+	return user
 }
 
 // createClientForContext creates a new KubernetesClient for the specified context.
+// The in-cluster configuration is only used for the "in-cluster" pseudo-context;
+// every other context is always resolved from the kubeconfig.
 func (m *MultiClusterClient) createClientForContext(context string) (*KubernetesClient, error) {
-	// First try in-cluster config (if running inside a pod)
-	if config, err := rest.InClusterConfig(); err == nil {
-		return &KubernetesClient{config: config}, nil
+	if m.isInClusterContext(context) {
+		config, err := inClusterConfig()
+		if err != nil {
+			return nil, fmt.Errorf("failed to load in-cluster config: %w", err)
+		}
+		return newKubernetesClient(m.configure(config), m.discoveryTTL)
 	}
 
-	// Fall back to kubeconfig with specific context
 	config, err := clientcmd.NewNonInteractiveDeferredLoadingClientConfig(
-		&clientcmd.ClientConfigLoadingRules{ExplicitPath: m.kubeconfig},
+		m.loadingRules,
 		&clientcmd.ConfigOverrides{CurrentContext: context},
 	).ClientConfig()
 
@@ -103,7 +214,35 @@ func (m *MultiClusterClient) createClientForContext(context string) (*Kubernetes
 		return nil, fmt.Errorf("failed to load kubeconfig for context '%s': %w", context, err)
 	}
 
-	return &KubernetesClient{config: config}, nil
+	return newKubernetesClient(m.configure(config), m.discoveryTTL)
+}
+
+// configure applies the client-side rate limits and user agent to config.
+func (m *MultiClusterClient) configure(config *rest.Config) *rest.Config {
+	if m.qps > 0 {
+		config.QPS = m.qps
+	}
+	if m.burst > 0 {
+		config.Burst = m.burst
+	}
+	if m.userAgent != "" {
+		config.UserAgent = m.userAgent
+	}
+	return config
+}
+
+// isInClusterContext reports whether context refers to the in-cluster pseudo-context.
+// A kubeconfig context that happens to use the same name takes precedence.
+func (m *MultiClusterClient) isInClusterContext(context string) bool {
+	if context != InClusterContext || !m.inCluster {
+		return false
+	}
+	config, err := m.loadingRules.Load()
+	if err != nil {
+		return true
+	}
+	_, defined := config.Contexts[context]
+	return !defined
 }
 
 // GetDefaultContext returns the default context name.
@@ -111,34 +250,58 @@ func (m *MultiClusterClient) GetDefaultContext() string {
 	return m.defaultContext
 }
 
-// ListContexts returns all available contexts from the kubeconfig.
-func (m *MultiClusterClient) ListContexts() ([]string, error) {
-	config, err := clientcmd.LoadFromFile(m.kubeconfig)
-	if err != nil {
+// ListContexts returns all available contexts from the merged kubeconfig files,
+// together with the file each context was loaded from.
+// The "in-cluster" pseudo-context is included when the server runs inside a pod.
+func (m *MultiClusterClient) ListContexts() ([]tools.ContextInfo, error) {
+	config, err := m.loadingRules.Load()
+	if err != nil && !m.inCluster {
 		return nil, fmt.Errorf("failed to load kubeconfig: %w", err)
 	}
 
-	contexts := make([]string, 0, len(config.Contexts))
-	for contextName := range config.Contexts {
-		contexts = append(contexts, contextName)
+	contexts := make([]tools.ContextInfo, 0)
+	if config != nil {
+		for contextName, kubeContext := range config.Contexts {
+			if !m.contextFilter.Allowed(contextName) {
+				continue
+			}
+			contexts = append(contexts, tools.ContextInfo{
+				Name:   contextName,
+				Source: kubeContext.LocationOfOrigin,
+			})
+		}
 	}
+	if m.isInClusterContext(InClusterContext) && m.contextFilter.Allowed(InClusterContext) {
+		contexts = append(contexts, tools.ContextInfo{
+			Name:   InClusterContext,
+			Source: inClusterSource,
+		})
+	}
+	sort.Slice(contexts, func(i, j int) bool {
+		return contexts[i].Name < contexts[j].Name
+	})
 
 	return contexts, nil
 }
 
-// getDefaultContext extracts the default context from kubeconfig.
-func getDefaultContext(kubeconfig string) (string, error) {
-	config, err := clientcmd.LoadFromFile(kubeconfig)
+// getDefaultContext extracts the default context from the merged kubeconfig.
+func getDefaultContext(loadingRules *clientcmd.ClientConfigLoadingRules) (string, error) {
+	config, err := loadingRules.Load()
 	if err != nil {
 		return "", fmt.Errorf("failed to load kubeconfig: %w", err)
 	}
 
 	if config.CurrentContext == "" {
-		// If no current context is set, try to find any available context
+		// If no current context is set, pick the first available context in name order
+		contextNames := make([]string, 0, len(config.Contexts))
 		for contextName := range config.Contexts {
-			return contextName, nil
+			contextNames = append(contextNames, contextName)
+		}
+		if len(contextNames) == 0 {
+			return "", fmt.Errorf("no contexts found in kubeconfig")
 		}
-		return "", fmt.Errorf("no contexts found in kubeconfig")
+		sort.Strings(contextNames)
+		return contextNames[0], nil
 	}
 
 	return config.CurrentContext, nil
@@ -165,6 +328,11 @@ func (c *ClientWrapper) Clientset() (*kubernetes.Clientset, error) {
 	return c.client.Clientset()
 }
 
+// InvalidateDiscovery drops the cached discovery results for this context.
+func (c *ClientWrapper) InvalidateDiscovery() {
+	c.client.InvalidateDiscovery()
+}
+
 // RESTMapper returns the REST mapper for this context.
 func (c *ClientWrapper) RESTMapper() (meta.RESTMapper, error) {
 	return c.client.RESTMapper()
@@ -175,6 +343,11 @@ func (c *ClientWrapper) ResourceInterface(gvr schema.GroupVersionResource, names
 	return c.client.ResourceInterface(gvr, namespaced, ns)
 }
 
+// RESTClient returns the REST client for this context.
+func (c *ClientWrapper) RESTClient() (rest.Interface, error) {
+	return c.client.RESTClient()
+}
+
 // GetContext returns the context name for this client.
 func (c *ClientWrapper) GetContext() string {
 	return c.context
@@ -183,6 +356,8 @@ func (c *ClientWrapper) GetContext() string {
 // Compile-time verification that ClientWrapper implements tools.Client interface
 var _ tools.Client = (*ClientWrapper)(nil)
 
+// Compile-time verification that ClientWrapper can refresh the discovery cache of its context
+var _ tools.DiscoveryInvalidator = (*ClientWrapper)(nil)
+
 // Compile-time verification that MultiClusterClient implements tools.MultiClusterClientInterface
 var _ tools.MultiClusterClientInterface = (*MultiClusterClient)(nil)
-
@@ -0,0 +1,294 @@
+package client
+
+import (
+	"context"
+	"os"
+	"path/filepath"
+	"slices"
+	"testing"
+
+	"github.com/kkb0318/kubernetes-mcp/src/auth"
+	"github.com/kkb0318/kubernetes-mcp/src/tools"
+	"github.com/stretchr/testify/assert"
+	"k8s.io/client-go/rest"
+)
+
+const testKubeconfig = `apiVersion: v1
+kind: Config
+clusters:
+- name: dev
+  cluster:
+    server: https://dev.example.com:6443
+- name: prod
+  cluster:
+    server: https://prod.example.com:6443
+users:
+- name: admin
+  user:
+    token: admin-token
+contexts:
+- name: dev
+  context:
+    cluster: dev
+    user: admin
+- name: prod
+  context:
+    cluster: prod
+    user: admin
+current-context: dev
+`
+
+func writeKubeconfig(t *testing.T, name, content string) string {
+	t.Helper()
+	path := filepath.Join(t.TempDir(), name)
+	if err := os.WriteFile(path, []byte(content), 0o600); err != nil {
+		t.Fatalf("failed to write kubeconfig: %v", err)
+	}
+	return path
+}
+
+func TestMultiClusterClient_KubeconfigList(t *testing.T) {
+	devProd := writeKubeconfig(t, "config", testKubeconfig)
+	gke := writeKubeconfig(t, "gke", `apiVersion: v1
+kind: Config
+clusters:
+- name: gke
+  cluster:
+    server: https://gke.example.com
+users:
+- name: gke-user
+  user:
+    token: gke-token
+contexts:
+- name: gke
+  context:
+    cluster: gke
+    user: gke-user
+current-context: gke
+`)
+	stubNotInCluster(t)
+	t.Setenv("KUBECONFIG", gke+string(filepath.ListSeparator)+devProd)
+
+	m, err := NewMultiClusterClient(Options{})
+	assert.NoError(t, err)
+
+	// The first file in the list sets the current context.
+	assert.Equal(t, "gke", m.GetDefaultContext())
+
+	contexts, err := m.ListContexts()
+	assert.NoError(t, err)
+	assert.Equal(t, []tools.ContextInfo{
+		{Name: "dev", Source: devProd},
+		{Name: "gke", Source: gke},
+		{Name: "prod", Source: devProd},
+	}, contexts)
+
+	c, err := m.GetClient(context.Background(), "prod")
+	assert.NoError(t, err)
+	assert.Equal(t, "https://prod.example.com:6443", c.(*ClientWrapper).client.config.Host)
+
+	c, err = m.GetClient(context.Background(), "")
+	assert.NoError(t, err)
+	assert.Equal(t, "https://gke.example.com", c.(*ClientWrapper).client.config.Host)
+}
+
+func TestMultiClusterClient_GetClientImpersonation(t *testing.T) {
+	t.Setenv("KUBECONFIG", writeKubeconfig(t, "config", testKubeconfig))
+
+	m, err := NewMultiClusterClient(Options{Impersonate: true, ImpersonateExtras: []string{"scopes"}})
+	assert.NoError(t, err)
+
+	alice := &auth.UserInfo{Name: "alice", Groups: []string{"dev", "ops"}, Extra: map[string][]string{
+		"scopes": {"read"},
+		"authentication.kubernetes.io/credential-id": {"JTI=1"},
+	}}
+	aliceCtx := auth.WithUser(context.Background(), alice)
+
+	c, err := m.GetClient(aliceCtx, "prod")
+	assert.NoError(t, err)
+	wrapper := c.(*ClientWrapper)
+	assert.Equal(t, "prod", wrapper.GetContext())
+	assert.Equal(t, "https://prod.example.com:6443", wrapper.client.config.Host)
+	assert.Equal(t, "alice", wrapper.client.config.Impersonate.UserName)
+	assert.Equal(t, []string{"dev", "ops"}, wrapper.client.config.Impersonate.Groups)
+	assert.Equal(t, map[string][]string{"scopes": {"read"}}, wrapper.client.config.Impersonate.Extra)
+
+	// Impersonating clients are built per request on the cached base client of the context
+	// and are not cached themselves.
+	again, err := m.GetClient(aliceCtx, "prod")
+	assert.NoError(t, err)
+	assert.NotSame(t, wrapper.client, again.(*ClientWrapper).client)
+	assert.Same(t, wrapper.client.base, again.(*ClientWrapper).client.base)
+
+	bob, err := m.GetClient(auth.WithUser(context.Background(), &auth.UserInfo{Name: "bob"}), "prod")
+	assert.NoError(t, err)
+	assert.Equal(t, "bob", bob.(*ClientWrapper).client.config.Impersonate.UserName)
+	assert.Same(t, wrapper.client.base, bob.(*ClientWrapper).client.base)
+	assert.Len(t, m.clients, 1)
+	assert.Same(t, m.clients["prod"], wrapper.client.base)
+
+	// Requests without an authenticated caller use the server's own identity.
+	anonymous, err := m.GetClient(context.Background(), "")
+	assert.NoError(t, err)
+	assert.Equal(t, "dev", anonymous.(*ClientWrapper).GetContext())
+	assert.Empty(t, anonymous.(*ClientWrapper).client.config.Impersonate.UserName)
+}
+
+func TestMultiClusterClient_GetClientWithoutImpersonation(t *testing.T) {
+	t.Setenv("KUBECONFIG", writeKubeconfig(t, "config", testKubeconfig))
+
+	m, err := NewMultiClusterClient(Options{})
+	assert.NoError(t, err)
+
+	c, err := m.GetClient(auth.WithUser(context.Background(), &auth.UserInfo{Name: "alice"}), "dev")
+	assert.NoError(t, err)
+	assert.Empty(t, c.(*ClientWrapper).client.config.Impersonate.UserName)
+}
+
+func stubInCluster(t *testing.T) {
+	t.Helper()
+	original := inClusterConfig
+	inClusterConfig = func() (*rest.Config, error) {
+		return &rest.Config{Host: "https://10.96.0.1:443"}, nil
+	}
+	t.Cleanup(func() { inClusterConfig = original })
+}
+
+func stubNotInCluster(t *testing.T) {
+	t.Helper()
+	original := inClusterConfig
+	inClusterConfig = func() (*rest.Config, error) {
+		return nil, rest.ErrNotInCluster
+	}
+	t.Cleanup(func() { inClusterConfig = original })
+}
+
+func TestMultiClusterClient_InClusterContext(t *testing.T) {
+	stubInCluster(t)
+	kubeconfig := writeKubeconfig(t, "config", testKubeconfig)
+	t.Setenv("KUBECONFIG", kubeconfig)
+
+	m, err := NewMultiClusterClient(Options{})
+	assert.NoError(t, err)
+	assert.Equal(t, "dev", m.GetDefaultContext())
+
+	// Kubeconfig contexts never resolve to the local cluster.
+	c, err := m.GetClient(context.Background(), "prod")
+	assert.NoError(t, err)
+	assert.Equal(t, "https://prod.example.com:6443", c.(*ClientWrapper).client.config.Host)
+
+	c, err = m.GetClient(context.Background(), InClusterContext)
+	assert.NoError(t, err)
+	assert.Equal(t, "https://10.96.0.1:443", c.(*ClientWrapper).client.config.Host)
+
+	_, err = m.GetClient(context.Background(), "prod-eu")
+	assert.Error(t, err)
+
+	contexts, err := m.ListContexts()
+	assert.NoError(t, err)
+	assert.Equal(t, []tools.ContextInfo{
+		{Name: "dev", Source: kubeconfig},
+		{Name: InClusterContext, Source: inClusterSource},
+		{Name: "prod", Source: kubeconfig},
+	}, contexts)
+}
+
+func TestMultiClusterClient_InClusterWithoutKubeconfig(t *testing.T) {
+	stubInCluster(t)
+	t.Setenv("KUBECONFIG", filepath.Join(t.TempDir(), "missing"))
+
+	m, err := NewMultiClusterClient(Options{})
+	assert.NoError(t, err)
+	assert.Equal(t, InClusterContext, m.GetDefaultContext())
+
+	c, err := m.GetClient(context.Background(), "")
+	assert.NoError(t, err)
+	assert.Equal(t, "https://10.96.0.1:443", c.(*ClientWrapper).client.config.Host)
+
+	contexts, err := m.ListContexts()
+	assert.NoError(t, err)
+	assert.Equal(t, []tools.ContextInfo{{Name: InClusterContext, Source: inClusterSource}}, contexts)
+}
+
+func TestNewMultiClusterClient_NoContexts(t *testing.T) {
+	stubNotInCluster(t)
+	t.Setenv("KUBECONFIG", filepath.Join(t.TempDir(), "missing"))
+
+	_, err := NewMultiClusterClient(Options{})
+	assert.Error(t, err)
+}
+
+func TestMultiClusterClient_Options(t *testing.T) {
+	stubNotInCluster(t)
+	t.Setenv("KUBECONFIG", "")
+
+	m, err := NewMultiClusterClient(Options{
+		Kubeconfig:      writeKubeconfig(t, "config", testKubeconfig),
+		DefaultContext:  "prod",
+		AllowedContexts: []string{"prod"},
+	})
+	assert.NoError(t, err)
+	assert.Equal(t, "prod", m.GetDefaultContext())
+
+	contexts, err := m.ListContexts()
+	assert.NoError(t, err)
+	assert.Len(t, contexts, 1)
+	assert.Equal(t, "prod", contexts[0].Name)
+
+	c, err := m.GetClient(context.Background(), "")
+	assert.NoError(t, err)
+	assert.Equal(t, "prod", c.(*ClientWrapper).GetContext())
+
+	_, err = m.GetClient(context.Background(), "dev")
+	assert.Error(t, err)
+	assert.Contains(t, err.Error(), "blocked by the server's context policy")
+}
+
+func TestMultiClusterClient_ContextPolicy(t *testing.T) {
+	stubInCluster(t)
+	t.Setenv("KUBECONFIG", writeKubeconfig(t, "config", testKubeconfig))
+
+	testCases := []struct {
+		name     string
+		allowed  []string
+		denied   []string
+		expected []string
+	}{
+		{name: "glob allow", allowed: []string{"d*", "in-*"}, expected: []string{"dev", "in-cluster"}},
+		{name: "regexp deny", denied: []string{"re:prod|in-cluster"}, expected: []string{"dev"}},
+		{name: "deny wins over allow", allowed: []string{"*"}, denied: []string{"prod"}, expected: []string{"dev", "in-cluster"}},
+	}
+
+	for _, tc := range testCases {
+		t.Run(tc.name, func(t *testing.T) {
+			m, err := NewMultiClusterClient(Options{AllowedContexts: tc.allowed, DeniedContexts: tc.denied})
+			assert.NoError(t, err)
+
+			contexts, err := m.ListContexts()
+			assert.NoError(t, err)
+			var names []string
+			for _, c := range contexts {
+				names = append(names, c.Name)
+			}
+			assert.Equal(t, tc.expected, names)
+
+			for _, name := range []string{"dev", "prod", InClusterContext} {
+				_, err := m.GetClient(context.Background(), name)
+				if slices.Contains(tc.expected, name) {
+					assert.NoError(t, err, name)
+				} else {
+					assert.Error(t, err, name)
+					assert.Contains(t, err.Error(), "blocked by the server's context policy")
+				}
+			}
+		})
+	}
+}
+
+func TestNewMultiClusterClient_InvalidContextPolicy(t *testing.T) {
+	t.Setenv("KUBECONFIG", writeKubeconfig(t, "config", testKubeconfig))
+
+	_, err := NewMultiClusterClient(Options{DeniedContexts: []string{"re:("}})
+	assert.Error(t, err)
+	assert.Contains(t, err.Error(), "invalid context policy")
+}
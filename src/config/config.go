@@ -0,0 +1,360 @@
+package config
+
+import (
+	"errors"
+	"flag"
+	"fmt"
+	"os"
+	"slices"
+	"strings"
+
+	"github.com/kkb0318/kubernetes-mcp/src/client"
+	"github.com/kkb0318/kubernetes-mcp/src/policy"
+	"github.com/kkb0318/kubernetes-mcp/src/prune"
+	"github.com/kkb0318/kubernetes-mcp/src/redact"
+	"github.com/kkb0318/kubernetes-mcp/src/tools"
+	"github.com/kkb0318/kubernetes-mcp/src/transport"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"sigs.k8s.io/yaml"
+)
+
+// EnvPrefix is prepended to a flag name, upper-cased with dashes replaced by underscores,
+// to form the environment variable that sets it, e.g. KUBERNETES_MCP_TRANSPORT.
+const EnvPrefix = "KUBERNETES_MCP_"
+
+// Config holds every server setting. Values are resolved in increasing order of precedence:
+// built-in defaults, the YAML config file, environment variables and command-line flags.
+type Config struct {
+	// Kubeconfig lists kubeconfig files separated by the OS path list separator. Empty uses KUBECONFIG or ~/.kube/config.
+	Kubeconfig string `json:"kubeconfig,omitempty"`
+	// DefaultContext is used when a request does not name a context. Empty uses the kubeconfig's current context.
+	DefaultContext string `json:"defaultContext,omitempty"`
+	// DefaultNamespace is used by tools that need a namespace when a request does not set one.
+	DefaultNamespace string `json:"defaultNamespace,omitempty"`
+	// AllowedContexts restricts the contexts that can be used to those matching a glob or "re:" regular expression. Empty means all contexts.
+	AllowedContexts []string `json:"allowedContexts,omitempty"`
+	// DeniedContexts blocks contexts matching a glob or "re:" regular expression, even if they are allowed.
+	DeniedContexts []string `json:"deniedContexts,omitempty"`
+	// AllowedNamespaces restricts the namespaces tools may read in contexts not covered by NamespacePolicies.
+	AllowedNamespaces []string `json:"allowedNamespaces,omitempty"`
+	// DeniedNamespaces blocks namespaces in contexts not covered by NamespacePolicies.
+	DeniedNamespaces []string `json:"deniedNamespaces,omitempty"`
+	// NamespacePolicies sets namespace rules per context. The first rule whose contexts match is used.
+	NamespacePolicies []policy.NamespaceRule `json:"namespacePolicies,omitempty"`
+	// DeniedResources blocks API resources, written as "resource" or "resource.group". Defaults to secrets.
+	DeniedResources []string `json:"deniedResources"`
+	// MetadataOnlyResources limits API resources to their metadata, type and data key names.
+	MetadataOnlyResources []string `json:"metadataOnlyResources,omitempty"`
+	// Impersonate makes Kubernetes API requests on behalf of the authenticated caller.
+	Impersonate bool `json:"impersonate,omitempty"`
+	// ImpersonateExtras lists the extra attributes of the caller forwarded when impersonating. Others are dropped.
+	ImpersonateExtras []string `json:"impersonateExtras,omitempty"`
+	// DiscoveryCacheTTL is how long API discovery results are cached per context, e.g. "5m".
+	DiscoveryCacheTTL metav1.Duration `json:"discoveryCacheTTL,omitempty"`
+	// KubeAPIQPS and KubeAPIBurst limit the requests sent to each cluster.
+	KubeAPIQPS   float64 `json:"kubeAPIQPS,omitempty"`
+	KubeAPIBurst int     `json:"kubeAPIBurst,omitempty"`
+
+	Transport TransportConfig `json:"transport"`
+	Auth      AuthConfig      `json:"auth"`
+	Limits    LimitsConfig    `json:"limits"`
+	Redaction RedactionConfig `json:"redaction"`
+	Pruning   PruningConfig   `json:"pruning"`
+	Tools     ToolsConfig     `json:"tools"`
+}
+
+// TransportConfig configures how clients connect to the server.
+type TransportConfig struct {
+	Type          string `json:"type,omitempty"`
+	ListenAddress string `json:"listenAddress,omitempty"`
+	BasePath      string `json:"basePath,omitempty"`
+	TLSCertFile   string `json:"tlsCertFile,omitempty"`
+	TLSKeyFile    string `json:"tlsKeyFile,omitempty"`
+	ClientCAFile  string `json:"clientCAFile,omitempty"`
+}
+
+// AuthConfig configures authentication for the network transports.
+type AuthConfig struct {
+	TokenFile            string   `json:"tokenFile,omitempty"`
+	TokenReview          bool     `json:"tokenReview,omitempty"`
+	TokenReviewAudiences []string `json:"tokenReviewAudiences,omitempty"`
+}
+
+// LimitsConfig holds the defaults applied when a tool request does not set them.
+type LimitsConfig struct {
+	TimeoutSeconds int64 `json:"timeoutSeconds,omitempty"`
+	EventsLimit    int64 `json:"eventsLimit,omitempty"`
+	LogTailLines   int64 `json:"logTailLines,omitempty"`
+}
+
+// RedactionConfig configures the removal of sensitive values from tool output.
+// The configured rules are added to the built-in ones unless DisableDefaultRules is set.
+type RedactionConfig struct {
+	Enabled             bool `json:"enabled"`
+	DisableDefaultRules bool `json:"disableDefaultRules,omitempty"`
+	redact.Rules
+}
+
+// PruningConfig configures the removal of noisy fields, such as managedFields, from returned objects.
+// The configured paths are added to the built-in ones unless DisableDefaultPaths is set.
+type PruningConfig struct {
+	Enabled             bool     `json:"enabled"`
+	DisableDefaultPaths bool     `json:"disableDefaultPaths,omitempty"`
+	Paths               []string `json:"paths,omitempty"`
+}
+
+// ToolsConfig selects which tools are registered.
+type ToolsConfig struct {
+	Enabled  []string `json:"enabled,omitempty"`
+	Disabled []string `json:"disabled,omitempty"`
+}
+
+// Default returns the configuration used when nothing is set.
+func Default() *Config {
+	toolDefaults := tools.DefaultOptions()
+	return &Config{
+		DefaultNamespace:  toolDefaults.DefaultNamespace,
+		DeniedResources:   slices.Clone(policy.DefaultDeniedResources),
+		DiscoveryCacheTTL: metav1.Duration{Duration: client.DefaultDiscoveryTTL},
+		KubeAPIQPS:        50,
+		KubeAPIBurst:      100,
+		Transport: TransportConfig{
+			Type:          transport.Stdio,
+			ListenAddress: ":8080",
+		},
+		Redaction: RedactionConfig{
+			Enabled: true,
+		},
+		Pruning: PruningConfig{
+			Enabled: true,
+		},
+		Limits: LimitsConfig{
+			TimeoutSeconds: toolDefaults.DefaultTimeoutSeconds,
+			EventsLimit:    toolDefaults.DefaultEventsLimit,
+			LogTailLines:   toolDefaults.DefaultLogTailLines,
+		},
+	}
+}
+
+// Load resolves the configuration from the command-line arguments (without the program name),
+// the environment and the config file named by --config or KUBERNETES_MCP_CONFIG.
+// It returns flag.ErrHelp if help was requested.
+func Load(args []string, lookupEnv func(string) (string, bool)) (*Config, error) {
+	cfg := Default()
+	var configFile string
+	fs := newFlagSet(cfg, &configFile)
+
+	if err := fs.Parse(args); err != nil {
+		return nil, err
+	}
+	if fs.NArg() > 0 {
+		return nil, fmt.Errorf("unexpected arguments: %v", fs.Args())
+	}
+
+	setOnCommandLine := make(map[string]bool)
+	fs.Visit(func(f *flag.Flag) {
+		setOnCommandLine[f.Name] = true
+	})
+
+	if !setOnCommandLine["config"] {
+		if value, ok := lookupEnv(envName("config")); ok {
+			configFile = value
+		}
+	}
+	if configFile != "" {
+		if err := loadFile(cfg, configFile); err != nil {
+			return nil, err
+		}
+	}
+
+	var envErr error
+	fs.VisitAll(func(f *flag.Flag) {
+		if setOnCommandLine[f.Name] || f.Name == "config" {
+			return
+		}
+		if value, ok := lookupEnv(envName(f.Name)); ok {
+			if err := fs.Set(f.Name, value); err != nil {
+				envErr = errors.Join(envErr, fmt.Errorf("invalid value %q for %s: %w", value, envName(f.Name), err))
+			}
+		}
+	})
+	if envErr != nil {
+		return nil, envErr
+	}
+
+	// Parse again so that command-line flags take precedence over the config file and environment
+	if err := fs.Parse(args); err != nil {
+		return nil, err
+	}
+
+	if err := cfg.Validate(); err != nil {
+		return nil, err
+	}
+	return cfg, nil
+}
+
+// loadFile merges the YAML file at path into cfg. Keys absent from the file keep their current values.
+func loadFile(cfg *Config, path string) error {
+	data, err := os.ReadFile(path)
+	if err != nil {
+		return fmt.Errorf("failed to read config file: %w", err)
+	}
+	if err := yaml.UnmarshalStrict(data, cfg); err != nil {
+		return fmt.Errorf("failed to parse config file %s: %w", path, err)
+	}
+	return nil
+}
+
+// Validate checks the configuration for values no component would accept.
+func (c *Config) Validate() error {
+	if c.Limits.TimeoutSeconds <= 0 {
+		return errors.New("limits.timeoutSeconds must be greater than 0")
+	}
+	if c.DiscoveryCacheTTL.Duration <= 0 {
+		return errors.New("discoveryCacheTTL must be greater than 0")
+	}
+	if c.KubeAPIQPS <= 0 {
+		return errors.New("kubeAPIQPS must be greater than 0")
+	}
+	if c.KubeAPIBurst <= 0 {
+		return errors.New("kubeAPIBurst must be greater than 0")
+	}
+	if c.Limits.EventsLimit < 0 {
+		return errors.New("limits.eventsLimit must not be negative")
+	}
+	if c.Limits.LogTailLines < 0 {
+		return errors.New("limits.logTailLines must not be negative")
+	}
+	if _, err := policy.NewFilter(c.AllowedContexts, c.DeniedContexts); err != nil {
+		return fmt.Errorf("invalid context policy: %w", err)
+	}
+	if _, err := c.namespacePolicy(); err != nil {
+		return fmt.Errorf("invalid namespace policy: %w", err)
+	}
+	if _, err := policy.NewResourcePolicy(c.DeniedResources, c.MetadataOnlyResources); err != nil {
+		return fmt.Errorf("invalid resource policy: %w", err)
+	}
+	if _, err := c.redactor(); err != nil {
+		return fmt.Errorf("invalid redaction rules: %w", err)
+	}
+	if _, err := c.pruner(); err != nil {
+		return fmt.Errorf("invalid pruning paths: %w", err)
+	}
+	if err := c.TransportOptions().Validate(); err != nil {
+		return fmt.Errorf("invalid transport configuration: %w", err)
+	}
+	if c.Impersonate && c.Transport.Type != transport.Stdio && !c.authEnabled() {
+		return fmt.Errorf("impersonate requires authentication on the %s transport: set clientCAFile, auth.tokenFile or auth.tokenReview", c.Transport.Type)
+	}
+	return nil
+}
+
+// authEnabled reports whether any authentication method is configured for the network transports.
+func (c *Config) authEnabled() bool {
+	return c.Transport.ClientCAFile != "" || c.Auth.TokenFile != "" || c.Auth.TokenReview
+}
+
+// ClientOptions returns the settings for the MultiClusterClient.
+func (c *Config) ClientOptions() client.Options {
+	return client.Options{
+		Kubeconfig:        c.Kubeconfig,
+		DefaultContext:    c.DefaultContext,
+		AllowedContexts:   c.AllowedContexts,
+		DeniedContexts:    c.DeniedContexts,
+		DiscoveryTTL:      c.DiscoveryCacheTTL.Duration,
+		QPS:               float32(c.KubeAPIQPS),
+		Burst:             c.KubeAPIBurst,
+		Impersonate:       c.Impersonate,
+		ImpersonateExtras: c.ImpersonateExtras,
+	}
+}
+
+// ToolOptions returns the settings shared by the tools.
+func (c *Config) ToolOptions() (tools.Options, error) {
+	namespaces, err := c.namespacePolicy()
+	if err != nil {
+		return tools.Options{}, fmt.Errorf("invalid namespace policy: %w", err)
+	}
+	resources, err := policy.NewResourcePolicy(c.DeniedResources, c.MetadataOnlyResources)
+	if err != nil {
+		return tools.Options{}, fmt.Errorf("invalid resource policy: %w", err)
+	}
+	redactor, err := c.redactor()
+	if err != nil {
+		return tools.Options{}, fmt.Errorf("invalid redaction rules: %w", err)
+	}
+	pruner, err := c.pruner()
+	if err != nil {
+		return tools.Options{}, fmt.Errorf("invalid pruning paths: %w", err)
+	}
+	return tools.Options{
+		DefaultTimeoutSeconds: c.Limits.TimeoutSeconds,
+		DefaultEventsLimit:    c.Limits.EventsLimit,
+		DefaultLogTailLines:   c.Limits.LogTailLines,
+		DefaultNamespace:      c.DefaultNamespace,
+		Namespaces:            namespaces,
+		Resources:             resources,
+		Redactor:              redactor,
+		Pruner:                pruner,
+		EnabledTools:          c.Tools.Enabled,
+		DisabledTools:         c.Tools.Disabled,
+	}, nil
+}
+
+// redactor compiles the redaction rules. It returns nil if redaction is disabled.
+func (c *Config) redactor() (*redact.Redactor, error) {
+	if !c.Redaction.Enabled {
+		return nil, nil
+	}
+	var rules redact.Rules
+	if !c.Redaction.DisableDefaultRules {
+		rules = redact.DefaultRules()
+	}
+	rules.Paths = append(rules.Paths, c.Redaction.Paths...)
+	rules.KeyPatterns = append(rules.KeyPatterns, c.Redaction.KeyPatterns...)
+	rules.ValuePatterns = append(rules.ValuePatterns, c.Redaction.ValuePatterns...)
+	return redact.New(rules)
+}
+
+// pruner compiles the pruning paths. It returns nil if pruning is disabled.
+func (c *Config) pruner() (*prune.Pruner, error) {
+	if !c.Pruning.Enabled {
+		return nil, nil
+	}
+	var paths []string
+	if !c.Pruning.DisableDefaultPaths {
+		paths = prune.DefaultPaths()
+	}
+	return prune.New(append(paths, c.Pruning.Paths...))
+}
+
+// namespacePolicy compiles the per-context namespace rules, followed by the global
+// allowed and denied namespaces as the rule for every other context.
+func (c *Config) namespacePolicy() (*policy.NamespacePolicy, error) {
+	rules := c.NamespacePolicies
+	if len(c.AllowedNamespaces) > 0 || len(c.DeniedNamespaces) > 0 {
+		rules = append(slices.Clip(rules), policy.NamespaceRule{
+			Allowed: c.AllowedNamespaces,
+			Denied:  c.DeniedNamespaces,
+		})
+	}
+	return policy.NewNamespacePolicy(rules)
+}
+
+// TransportOptions returns the settings for serving the MCP server.
+// The Authenticator is left for the caller to build, since it may need a cluster client.
+func (c *Config) TransportOptions() transport.Options {
+	return transport.Options{
+		Transport:    c.Transport.Type,
+		Address:      c.Transport.ListenAddress,
+		BasePath:     c.Transport.BasePath,
+		TLSCertFile:  c.Transport.TLSCertFile,
+		TLSKeyFile:   c.Transport.TLSKeyFile,
+		ClientCAFile: c.Transport.ClientCAFile,
+	}
+}
+
+// envName returns the environment variable that sets the named flag.
+func envName(flagName string) string {
+	return EnvPrefix + strings.ToUpper(strings.ReplaceAll(flagName, "-", "_"))
+}
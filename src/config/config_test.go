@@ -0,0 +1,332 @@
+package config
+
+import (
+	"errors"
+	"flag"
+	"os"
+	"path/filepath"
+	"testing"
+	"time"
+
+	"github.com/kkb0318/kubernetes-mcp/src/tools"
+	"github.com/stretchr/testify/assert"
+	"k8s.io/apimachinery/pkg/runtime/schema"
+)
+
+func env(values map[string]string) func(string) (string, bool) {
+	return func(key string) (string, bool) {
+		value, ok := values[key]
+		return value, ok
+	}
+}
+
+func writeConfig(t *testing.T, content string) string {
+	t.Helper()
+	path := filepath.Join(t.TempDir(), "config.yaml")
+	if err := os.WriteFile(path, []byte(content), 0o600); err != nil {
+		t.Fatalf("failed to write config: %v", err)
+	}
+	return path
+}
+
+func TestLoad_Defaults(t *testing.T) {
+	cfg, err := Load(nil, env(nil))
+	assert.NoError(t, err)
+	assert.Equal(t, Default(), cfg)
+	toolOpts, err := cfg.ToolOptions()
+	assert.NoError(t, err)
+	assert.Equal(t, tools.DefaultOptions(), toolOpts)
+}
+
+func TestLoad_Precedence(t *testing.T) {
+	path := writeConfig(t, `
+kubeconfig: /etc/kube/a:/etc/kube/b
+defaultContext: file-context
+defaultNamespace: file-namespace
+discoveryCacheTTL: 10m
+allowedContexts: [dev, prod]
+transport:
+  type: http
+  listenAddress: ":9090"
+limits:
+  timeoutSeconds: 10
+  eventsLimit: 20
+  logTailLines: 30
+tools:
+  disabled: [get_pod_logs]
+`)
+
+	testCases := []struct {
+		name     string
+		args     []string
+		env      map[string]string
+		validate func(t *testing.T, cfg *Config)
+	}{
+		{
+			name: "config file from flag",
+			args: []string{"--config", path},
+			validate: func(t *testing.T, cfg *Config) {
+				assert.Equal(t, "/etc/kube/a:/etc/kube/b", cfg.Kubeconfig)
+				assert.Equal(t, "file-context", cfg.DefaultContext)
+				assert.Equal(t, []string{"dev", "prod"}, cfg.AllowedContexts)
+				assert.Equal(t, "http", cfg.Transport.Type)
+				assert.Equal(t, ":9090", cfg.Transport.ListenAddress)
+				assert.Equal(t, 10*time.Minute, cfg.ClientOptions().DiscoveryTTL)
+				assert.Equal(t, float32(50), cfg.ClientOptions().QPS)
+				assert.Equal(t, 100, cfg.ClientOptions().Burst)
+				toolOpts, err := cfg.ToolOptions()
+				assert.NoError(t, err)
+				assert.Equal(t, tools.Options{
+					DefaultTimeoutSeconds: 10,
+					DefaultEventsLimit:    20,
+					DefaultLogTailLines:   30,
+					DefaultNamespace:      "file-namespace",
+					Resources:             tools.DefaultOptions().Resources,
+					Redactor:              tools.DefaultOptions().Redactor,
+					Pruner:                tools.DefaultOptions().Pruner,
+					DisabledTools:         []string{"get_pod_logs"},
+				}, toolOpts)
+			},
+		},
+		{
+			name: "config file from environment",
+			env:  map[string]string{"KUBERNETES_MCP_CONFIG": path},
+			validate: func(t *testing.T, cfg *Config) {
+				assert.Equal(t, "file-context", cfg.DefaultContext)
+			},
+		},
+		{
+			name: "environment overrides config file",
+			args: []string{"--config", path},
+			env: map[string]string{
+				"KUBERNETES_MCP_CONTEXT":             "env-context",
+				"KUBERNETES_MCP_ALLOWED_CONTEXTS":    "staging, prod",
+				"KUBERNETES_MCP_DENIED_CONTEXTS":     "re:.*-admin",
+				"KUBERNETES_MCP_EVENTS_LIMIT":        "50",
+				"KUBERNETES_MCP_IMPERSONATE":         "true",
+				"KUBERNETES_MCP_IMPERSONATE_EXTRAS":  "scopes",
+				"KUBERNETES_MCP_AUTH_TOKEN_REVIEW":   "true",
+				"KUBERNETES_MCP_DISCOVERY_CACHE_TTL": "90s",
+			},
+			validate: func(t *testing.T, cfg *Config) {
+				assert.Equal(t, "env-context", cfg.DefaultContext)
+				assert.Equal(t, []string{"staging", "prod"}, cfg.AllowedContexts)
+				assert.Equal(t, []string{"re:.*-admin"}, cfg.ClientOptions().DeniedContexts)
+				assert.Equal(t, int64(50), cfg.Limits.EventsLimit)
+				assert.Equal(t, int64(10), cfg.Limits.TimeoutSeconds)
+				assert.True(t, cfg.Impersonate)
+				assert.Equal(t, []string{"scopes"}, cfg.ClientOptions().ImpersonateExtras)
+				assert.Equal(t, 90*time.Second, cfg.ClientOptions().DiscoveryTTL)
+			},
+		},
+		{
+			name: "flags override environment and config file",
+			args: []string{"--config", path, "--context", "flag-context", "--events-limit=5", "--enabled-tools", "list_resources,list_events", "--kube-api-qps", "20.5"},
+			env: map[string]string{
+				"KUBERNETES_MCP_CONTEXT":      "env-context",
+				"KUBERNETES_MCP_EVENTS_LIMIT": "50",
+			},
+			validate: func(t *testing.T, cfg *Config) {
+				assert.Equal(t, "flag-context", cfg.DefaultContext)
+				assert.Equal(t, int64(5), cfg.Limits.EventsLimit)
+				assert.Equal(t, []string{"list_resources", "list_events"}, cfg.Tools.Enabled)
+				assert.Equal(t, []string{"get_pod_logs"}, cfg.Tools.Disabled)
+				assert.Equal(t, float32(20.5), cfg.ClientOptions().QPS)
+			},
+		},
+	}
+
+	for _, tc := range testCases {
+		t.Run(tc.name, func(t *testing.T) {
+			cfg, err := Load(tc.args, env(tc.env))
+			assert.NoError(t, err)
+			tc.validate(t, cfg)
+		})
+	}
+}
+
+func TestLoad_Errors(t *testing.T) {
+	testCases := []struct {
+		name          string
+		args          []string
+		env           map[string]string
+		expectedError string
+	}{
+		{
+			name:          "unknown key in config file",
+			args:          []string{"--config", writeConfig(t, "defaultNamespce: foo\n")},
+			expectedError: "failed to parse config file",
+		},
+		{
+			name:          "missing config file",
+			args:          []string{"--config", filepath.Join(t.TempDir(), "missing.yaml")},
+			expectedError: "failed to read config file",
+		},
+		{
+			name:          "invalid environment value",
+			env:           map[string]string{"KUBERNETES_MCP_TIMEOUT_SECONDS": "soon"},
+			expectedError: "KUBERNETES_MCP_TIMEOUT_SECONDS",
+		},
+		{
+			name:          "invalid timeout",
+			args:          []string{"--timeout-seconds", "0"},
+			expectedError: "timeoutSeconds must be greater than 0",
+		},
+		{
+			name:          "invalid discovery cache TTL",
+			args:          []string{"--discovery-cache-ttl", "0s"},
+			expectedError: "discoveryCacheTTL must be greater than 0",
+		},
+		{
+			name:          "invalid QPS",
+			args:          []string{"--kube-api-qps", "-1"},
+			expectedError: "kubeAPIQPS must be greater than 0",
+		},
+		{
+			name:          "invalid context pattern",
+			args:          []string{"--denied-contexts", "re:prod("},
+			expectedError: "invalid context policy",
+		},
+		{
+			name:          "invalid namespace pattern",
+			args:          []string{"--config", writeConfig(t, "namespacePolicies:\n- contexts: [prod]\n  denied: [\"re:[\"]\n")},
+			expectedError: "invalid namespace policy",
+		},
+		{
+			name:          "invalid transport",
+			args:          []string{"--transport", "websocket"},
+			expectedError: "invalid transport configuration",
+		},
+		{
+			name:          "impersonate without authentication",
+			args:          []string{"--transport", "http", "--impersonate"},
+			expectedError: "impersonate requires authentication on the http transport",
+		},
+		{
+			name:          "positional arguments",
+			args:          []string{"extra"},
+			expectedError: "unexpected arguments",
+		},
+	}
+
+	for _, tc := range testCases {
+		t.Run(tc.name, func(t *testing.T) {
+			_, err := Load(tc.args, env(tc.env))
+			assert.Error(t, err)
+			assert.Contains(t, err.Error(), tc.expectedError)
+		})
+	}
+}
+
+func TestLoad_Help(t *testing.T) {
+	_, err := Load([]string{"--help"}, env(nil))
+	assert.True(t, errors.Is(err, flag.ErrHelp))
+}
+
+func TestConfig_ResourcePolicy(t *testing.T) {
+	secrets := schema.GroupVersionResource{Version: "v1", Resource: "secrets"}
+
+	cfg, err := Load(nil, env(nil))
+	assert.NoError(t, err)
+	toolOpts, err := cfg.ToolOptions()
+	assert.NoError(t, err)
+	assert.True(t, toolOpts.Resources.Denied(secrets))
+
+	cfg, err = Load([]string{"--config", writeConfig(t, "deniedResources: []\nmetadataOnlyResources: [secrets]\n")}, env(nil))
+	assert.NoError(t, err)
+	toolOpts, err = cfg.ToolOptions()
+	assert.NoError(t, err)
+	assert.False(t, toolOpts.Resources.Denied(secrets))
+	assert.True(t, toolOpts.Resources.MetadataOnly(secrets))
+
+	cfg, err = Load(nil, env(map[string]string{"KUBERNETES_MCP_DENIED_RESOURCES": ""}))
+	assert.NoError(t, err)
+	assert.Empty(t, cfg.DeniedResources)
+}
+
+func TestConfig_Redaction(t *testing.T) {
+	cfg, err := Load([]string{"--config", writeConfig(t, `
+redaction:
+  paths: ["data.tls\\.key"]
+  valuePatterns: ["internal-[0-9]+"]
+`)}, env(nil))
+	assert.NoError(t, err)
+	toolOpts, err := cfg.ToolOptions()
+	assert.NoError(t, err)
+
+	text, count := toolOpts.Redactor.String("password=hunter2 host=internal-42")
+	assert.Equal(t, "password=[REDACTED] host=[REDACTED]", text)
+	assert.Equal(t, 2, count)
+	v, count := toolOpts.Redactor.Value(map[string]any{"data": map[string]any{"tls.key": "key"}})
+	assert.Equal(t, map[string]any{"data": map[string]any{"tls.key": "[REDACTED]"}}, v)
+	assert.Equal(t, 1, count)
+
+	cfg, err = Load([]string{"--config", writeConfig(t, "redaction:\n  disableDefaultRules: true\n  keyPatterns: [\"^pin$\"]\n")}, env(nil))
+	assert.NoError(t, err)
+	toolOpts, err = cfg.ToolOptions()
+	assert.NoError(t, err)
+	text, count = toolOpts.Redactor.String("password=hunter2")
+	assert.Equal(t, "password=hunter2", text)
+	assert.Zero(t, count)
+
+	cfg, err = Load([]string{"--redact=false"}, env(nil))
+	assert.NoError(t, err)
+	toolOpts, err = cfg.ToolOptions()
+	assert.NoError(t, err)
+	assert.Nil(t, toolOpts.Redactor)
+
+	_, err = Load([]string{"--redact-key-patterns", "("}, env(nil))
+	assert.Error(t, err)
+	assert.Contains(t, err.Error(), "invalid redaction rules")
+}
+
+func TestConfig_Pruning(t *testing.T) {
+	cfg, err := Load([]string{"--prune-paths", "status.conditions.lastProbeTime"}, env(nil))
+	assert.NoError(t, err)
+	toolOpts, err := cfg.ToolOptions()
+	assert.NoError(t, err)
+
+	obj := map[string]any{
+		"metadata": map[string]any{"name": "web", "managedFields": []any{}},
+		"status":   map[string]any{"conditions": []any{map[string]any{"type": "Ready", "lastProbeTime": nil}}},
+	}
+	assert.Equal(t, 2, toolOpts.Pruner.Object(obj))
+	assert.Equal(t, map[string]any{
+		"metadata": map[string]any{"name": "web"},
+		"status":   map[string]any{"conditions": []any{map[string]any{"type": "Ready"}}},
+	}, obj)
+
+	cfg, err = Load([]string{"--config", writeConfig(t, "pruning:\n  disableDefaultPaths: true\n  paths: [\"metadata.generation\"]\n")}, env(nil))
+	assert.NoError(t, err)
+	toolOpts, err = cfg.ToolOptions()
+	assert.NoError(t, err)
+	obj = map[string]any{"metadata": map[string]any{"generation": 2, "managedFields": []any{}}}
+	assert.Equal(t, 1, toolOpts.Pruner.Object(obj))
+	assert.Equal(t, map[string]any{"metadata": map[string]any{"managedFields": []any{}}}, obj)
+
+	cfg, err = Load(nil, env(map[string]string{"KUBERNETES_MCP_PRUNE": "false"}))
+	assert.NoError(t, err)
+	toolOpts, err = cfg.ToolOptions()
+	assert.NoError(t, err)
+	assert.Nil(t, toolOpts.Pruner)
+}
+
+func TestConfig_NamespacePolicy(t *testing.T) {
+	path := writeConfig(t, `
+namespacePolicies:
+- contexts: ["prod-*"]
+  allowed: ["app-*"]
+deniedNamespaces: [kube-system]
+`)
+	cfg, err := Load([]string{"--config", path}, env(nil))
+	assert.NoError(t, err)
+
+	toolOpts, err := cfg.ToolOptions()
+	assert.NoError(t, err)
+	prod := toolOpts.Namespaces.ForContext("prod-eu")
+	assert.True(t, prod.Allowed("app-web"))
+	assert.False(t, prod.Allowed("default"))
+	dev := toolOpts.Namespaces.ForContext("dev")
+	assert.True(t, dev.Allowed("default"))
+	assert.False(t, dev.Allowed("kube-system"))
+}
@@ -0,0 +1,84 @@
+package config
+
+import (
+	"flag"
+	"os"
+	"strings"
+)
+
+// newFlagSet binds the command-line flags to the fields of cfg.
+func newFlagSet(cfg *Config, configFile *string) *flag.FlagSet {
+	fs := flag.NewFlagSet("kubernetes-mcp", flag.ContinueOnError)
+	fs.SetOutput(os.Stderr)
+
+	fs.StringVar(configFile, "config", "", "Path to a YAML config file")
+
+	fs.StringVar(&cfg.Kubeconfig, "kubeconfig", cfg.Kubeconfig, "Kubeconfig files to use, separated like KUBECONFIG (default: KUBECONFIG or ~/.kube/config)")
+	fs.StringVar(&cfg.DefaultContext, "context", cfg.DefaultContext, "Context used when a request does not name one (default: the kubeconfig's current context)")
+	fs.StringVar(&cfg.DefaultNamespace, "namespace", cfg.DefaultNamespace, "Namespace used by tools that need one when a request does not set it")
+	fs.Var(newStringSliceValue(&cfg.AllowedContexts), "allowed-contexts", "Comma-separated glob or \"re:\" regular expression patterns of contexts that may be used (default: all)")
+	fs.Var(newStringSliceValue(&cfg.DeniedContexts), "denied-contexts", "Comma-separated glob or \"re:\" regular expression patterns of contexts that may not be used")
+	fs.Var(newStringSliceValue(&cfg.AllowedNamespaces), "allowed-namespaces", "Comma-separated glob or \"re:\" regular expression patterns of namespaces tools may read (default: all)")
+	fs.Var(newStringSliceValue(&cfg.DeniedNamespaces), "denied-namespaces", "Comma-separated glob or \"re:\" regular expression patterns of namespaces tools may not read")
+	fs.Var(newStringSliceValue(&cfg.DeniedResources), "denied-resources", "Comma-separated resources tools may not read, as resource or resource.group (set to \"\" to allow secrets)")
+	fs.Var(newStringSliceValue(&cfg.MetadataOnlyResources), "metadata-only-resources", "Comma-separated resources returned as names, types and data key names only, as resource or resource.group")
+	fs.DurationVar(&cfg.DiscoveryCacheTTL.Duration, "discovery-cache-ttl", cfg.DiscoveryCacheTTL.Duration, "How long API discovery results are cached per context")
+	fs.Float64Var(&cfg.KubeAPIQPS, "kube-api-qps", cfg.KubeAPIQPS, "Maximum sustained requests per second sent to each cluster")
+	fs.IntVar(&cfg.KubeAPIBurst, "kube-api-burst", cfg.KubeAPIBurst, "Maximum burst of requests sent to each cluster")
+	fs.BoolVar(&cfg.Impersonate, "impersonate", cfg.Impersonate, "Impersonate the authenticated caller on every Kubernetes API request")
+	fs.Var(newStringSliceValue(&cfg.ImpersonateExtras), "impersonate-extras", "Comma-separated extra attributes of the caller forwarded when impersonating (default: none)")
+
+	fs.StringVar(&cfg.Transport.Type, "transport", cfg.Transport.Type, "Transport to serve MCP on: stdio, sse or http (streamable HTTP)")
+	fs.StringVar(&cfg.Transport.ListenAddress, "listen-address", cfg.Transport.ListenAddress, "Address to listen on for the sse and http transports")
+	fs.StringVar(&cfg.Transport.BasePath, "base-path", cfg.Transport.BasePath, "URL path prefix for the MCP endpoints of the sse and http transports")
+	fs.StringVar(&cfg.Transport.TLSCertFile, "tls-cert-file", cfg.Transport.TLSCertFile, "TLS certificate file for the sse and http transports")
+	fs.StringVar(&cfg.Transport.TLSKeyFile, "tls-key-file", cfg.Transport.TLSKeyFile, "TLS private key file for the sse and http transports")
+	fs.StringVar(&cfg.Transport.ClientCAFile, "client-ca-file", cfg.Transport.ClientCAFile, "CA bundle used to verify TLS client certificates; enables client certificate authentication")
+
+	fs.StringVar(&cfg.Auth.TokenFile, "auth-token-file", cfg.Auth.TokenFile, "CSV file of static bearer tokens (token,user,uid,\"group1,group2\")")
+	fs.BoolVar(&cfg.Auth.TokenReview, "auth-token-review", cfg.Auth.TokenReview, "Authenticate bearer tokens, such as ServiceAccount tokens, with the Kubernetes TokenReview API")
+	fs.Var(newStringSliceValue(&cfg.Auth.TokenReviewAudiences), "auth-token-review-audiences", "Comma-separated audiences that reviewed tokens must be issued for")
+
+	fs.Int64Var(&cfg.Limits.TimeoutSeconds, "timeout-seconds", cfg.Limits.TimeoutSeconds, "Default timeout for list operations in seconds")
+	fs.Int64Var(&cfg.Limits.EventsLimit, "events-limit", cfg.Limits.EventsLimit, "Default maximum number of events returned by list_events (0 for no limit)")
+	fs.Int64Var(&cfg.Limits.LogTailLines, "log-tail-lines", cfg.Limits.LogTailLines, "Default number of log lines returned by get_pod_logs (0 for all)")
+
+	fs.BoolVar(&cfg.Redaction.Enabled, "redact", cfg.Redaction.Enabled, "Redact sensitive values such as passwords and tokens from tool output")
+	fs.Var(newStringSliceValue(&cfg.Redaction.Paths), "redact-paths", "Comma-separated object paths whose values are always redacted, e.g. data.tls\\.key")
+	fs.Var(newStringSliceValue(&cfg.Redaction.KeyPatterns), "redact-key-patterns", "Comma-separated regular expressions for keys whose values are redacted")
+
+	fs.BoolVar(&cfg.Pruning.Enabled, "prune", cfg.Pruning.Enabled, "Remove managedFields, the last-applied-configuration annotation and other noisy fields from returned objects")
+	fs.Var(newStringSliceValue(&cfg.Pruning.Paths), "prune-paths", "Comma-separated object paths removed from returned objects, e.g. metadata.annotations.deployment\\.kubernetes\\.io/revision")
+
+	fs.Var(newStringSliceValue(&cfg.Tools.Enabled), "enabled-tools", "Comma-separated tools to register (default: all)")
+	fs.Var(newStringSliceValue(&cfg.Tools.Disabled), "disabled-tools", "Comma-separated tools not to register")
+
+	return fs
+}
+
+// stringSliceValue is a flag.Value for comma-separated lists. Setting it replaces the whole list.
+type stringSliceValue struct {
+	values *[]string
+}
+
+func newStringSliceValue(values *[]string) *stringSliceValue {
+	return &stringSliceValue{values: values}
+}
+
+func (s *stringSliceValue) String() string {
+	if s == nil || s.values == nil {
+		return ""
+	}
+	return strings.Join(*s.values, ",")
+}
+
+func (s *stringSliceValue) Set(value string) error {
+	var values []string
+	for _, v := range strings.Split(value, ",") {
+		if v = strings.TrimSpace(v); v != "" {
+			values = append(values, v)
+		}
+	}
+	*s.values = values
+	return nil
+}
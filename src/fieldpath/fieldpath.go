@@ -0,0 +1,71 @@
+// Package fieldpath matches the paths to fields of decoded objects against dot-separated patterns.
+package fieldpath
+
+import (
+	"fmt"
+	"strings"
+)
+
+// Patterns is a compiled set of path patterns. The nil Patterns matches nothing.
+type Patterns [][]string
+
+// Compile compiles paths. Paths are dot-separated and match the end of the path to a field, so
+// "annotations.foo" matches "spec.template.metadata.annotations.foo" too. Array indexes are not
+// part of paths, "*" matches any key and a literal dot in a key is escaped as "\.".
+func Compile(paths []string) (Patterns, error) {
+	var patterns Patterns
+	for _, path := range paths {
+		segments := split(path)
+		if len(segments) == 0 {
+			return nil, fmt.Errorf("invalid path %q: empty path", path)
+		}
+		patterns = append(patterns, segments)
+	}
+	return patterns, nil
+}
+
+// Match reports whether any pattern matches the end of path, the keys leading to a field.
+func (p Patterns) Match(path []string) bool {
+	for _, pattern := range p {
+		if len(pattern) > len(path) {
+			continue
+		}
+		suffix := path[len(path)-len(pattern):]
+		matched := true
+		for i, segment := range pattern {
+			if segment != "*" && segment != suffix[i] {
+				matched = false
+				break
+			}
+		}
+		if matched {
+			return true
+		}
+	}
+	return false
+}
+
+// split splits a path on dots that are not escaped with a backslash.
+func split(path string) []string {
+	var segments []string
+	var current strings.Builder
+	escaped := false
+	for _, c := range path {
+		switch {
+		case escaped:
+			current.WriteRune(c)
+			escaped = false
+		case c == '\\':
+			escaped = true
+		case c == '.':
+			segments = append(segments, current.String())
+			current.Reset()
+		default:
+			current.WriteRune(c)
+		}
+	}
+	if path != "" {
+		segments = append(segments, current.String())
+	}
+	return segments
+}
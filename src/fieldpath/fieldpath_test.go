@@ -0,0 +1,43 @@
+package fieldpath
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func TestPatterns_Match(t *testing.T) {
+	patterns, err := Compile([]string{
+		`metadata.managedFields`,
+		`annotations.kubectl\.kubernetes\.io/last-applied-configuration`,
+		`status.*.lastProbeTime`,
+	})
+	assert.NoError(t, err)
+
+	testCases := []struct {
+		path     []string
+		expected bool
+	}{
+		{path: []string{"metadata", "managedFields"}, expected: true},
+		{path: []string{"spec", "template", "metadata", "managedFields"}, expected: true},
+		{path: []string{"managedFields"}},
+		{path: []string{"metadata", "annotations", "kubectl.kubernetes.io/last-applied-configuration"}, expected: true},
+		{path: []string{"metadata", "annotations", "kubectl"}},
+		{path: []string{"status", "conditions", "lastProbeTime"}, expected: true},
+		{path: []string{"status", "lastProbeTime"}},
+	}
+
+	for _, tc := range testCases {
+		assert.Equal(t, tc.expected, patterns.Match(tc.path), tc.path)
+	}
+}
+
+func TestPatterns_Nil(t *testing.T) {
+	var patterns Patterns
+	assert.False(t, patterns.Match([]string{"metadata"}))
+}
+
+func TestCompile_InvalidPath(t *testing.T) {
+	_, err := Compile([]string{""})
+	assert.Error(t, err)
+}
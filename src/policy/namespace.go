@@ -0,0 +1,61 @@
+package policy
+
+import (
+	"fmt"
+)
+
+// NamespaceRule restricts the namespaces that can be used in the contexts it applies to.
+// Contexts, Allowed and Denied hold glob or "re:" regular expression patterns.
+type NamespaceRule struct {
+	// Contexts selects the contexts the rule applies to. Empty means every context.
+	Contexts []string `json:"contexts,omitempty"`
+	// Allowed restricts the namespaces to those matching one of these patterns. Empty means all namespaces.
+	Allowed []string `json:"allowed,omitempty"`
+	// Denied blocks namespaces matching any of these patterns, even if they are allowed.
+	Denied []string `json:"denied,omitempty"`
+}
+
+// NamespacePolicy picks the namespace filter for a context from an ordered list of rules.
+// The first rule whose contexts match is used; contexts matched by no rule are unrestricted.
+// The nil policy allows every namespace.
+type NamespacePolicy struct {
+	rules []namespaceRule
+}
+
+type namespaceRule struct {
+	contexts *Filter
+	filter   *Filter
+}
+
+// NewNamespacePolicy compiles the rules. It returns nil if there are none.
+func NewNamespacePolicy(rules []NamespaceRule) (*NamespacePolicy, error) {
+	if len(rules) == 0 {
+		return nil, nil
+	}
+	policy := &NamespacePolicy{}
+	for i, rule := range rules {
+		contexts, err := NewFilter(rule.Contexts, nil)
+		if err != nil {
+			return nil, fmt.Errorf("namespace rule %d: invalid contexts: %w", i, err)
+		}
+		filter, err := NewFilter(rule.Allowed, rule.Denied)
+		if err != nil {
+			return nil, fmt.Errorf("namespace rule %d: %w", i, err)
+		}
+		policy.rules = append(policy.rules, namespaceRule{contexts: contexts, filter: filter})
+	}
+	return policy, nil
+}
+
+// ForContext returns the namespace filter for the named context. A nil filter allows everything.
+func (p *NamespacePolicy) ForContext(contextName string) *Filter {
+	if p == nil {
+		return nil
+	}
+	for _, rule := range p.rules {
+		if rule.contexts.Allowed(contextName) {
+			return rule.filter
+		}
+	}
+	return nil
+}
@@ -0,0 +1,125 @@
+// Package policy implements the allow and deny rules the server applies to names such as
+// kubeconfig contexts and namespaces.
+package policy
+
+import (
+	"fmt"
+	"regexp"
+	"strings"
+)
+
+// regexpPrefix marks a pattern as a regular expression instead of a glob.
+const regexpPrefix = "re:"
+
+// Pattern matches a whole name. It is a glob where "*" matches any run of characters and "?"
+// a single character, or a regular expression when prefixed with "re:". Both must match the
+// entire name, so "prod-*" does not match "my-prod-1".
+type Pattern struct {
+	raw string
+	re  *regexp.Regexp
+}
+
+// ParsePattern compiles a glob or "re:" regular expression.
+func ParsePattern(pattern string) (Pattern, error) {
+	if pattern == "" {
+		return Pattern{}, fmt.Errorf("empty pattern")
+	}
+	expr, isRegexp := strings.CutPrefix(pattern, regexpPrefix)
+	if !isRegexp {
+		expr = globToRegexp(pattern)
+	}
+	re, err := regexp.Compile("^(?:" + expr + ")$")
+	if err != nil {
+		return Pattern{}, fmt.Errorf("invalid pattern %q: %w", pattern, err)
+	}
+	return Pattern{raw: pattern, re: re}, nil
+}
+
+// Match reports whether name matches the pattern.
+func (p Pattern) Match(name string) bool {
+	return p.re.MatchString(name)
+}
+
+// String returns the pattern as it was written.
+func (p Pattern) String() string {
+	return p.raw
+}
+
+// globToRegexp translates a glob into an unanchored regular expression.
+func globToRegexp(glob string) string {
+	var b strings.Builder
+	for _, r := range glob {
+		switch r {
+		case '*':
+			b.WriteString(".*")
+		case '?':
+			b.WriteString(".")
+		default:
+			b.WriteString(regexp.QuoteMeta(string(r)))
+		}
+	}
+	return b.String()
+}
+
+// Filter allows a name when it matches at least one allow pattern, or there are none,
+// and matches no deny pattern. Deny always wins over allow. The zero Filter allows everything.
+type Filter struct {
+	allow []Pattern
+	deny  []Pattern
+}
+
+// NewFilter compiles the allow and deny patterns.
+func NewFilter(allow, deny []string) (*Filter, error) {
+	allowPatterns, err := parsePatterns(allow)
+	if err != nil {
+		return nil, err
+	}
+	denyPatterns, err := parsePatterns(deny)
+	if err != nil {
+		return nil, err
+	}
+	return &Filter{allow: allowPatterns, deny: denyPatterns}, nil
+}
+
+func parsePatterns(patterns []string) ([]Pattern, error) {
+	parsed := make([]Pattern, 0, len(patterns))
+	for _, pattern := range patterns {
+		p, err := ParsePattern(pattern)
+		if err != nil {
+			return nil, err
+		}
+		parsed = append(parsed, p)
+	}
+	return parsed, nil
+}
+
+// Allowed reports whether name passes the filter.
+func (f *Filter) Allowed(name string) bool {
+	return f.Check(name) == nil
+}
+
+// Check returns an error explaining why name is rejected, or nil if it is allowed.
+func (f *Filter) Check(name string) error {
+	if f == nil {
+		return nil
+	}
+	for _, p := range f.deny {
+		if p.Match(name) {
+			return fmt.Errorf("matches denied pattern %q", p)
+		}
+	}
+	if len(f.allow) == 0 {
+		return nil
+	}
+	for _, p := range f.allow {
+		if p.Match(name) {
+			return nil
+		}
+	}
+	return fmt.Errorf("does not match any allowed pattern")
+}
+
+// IsZero reports whether the filter has no rules and therefore allows everything.
+func (f *Filter) IsZero() bool {
+	return f == nil || (len(f.allow) == 0 && len(f.deny) == 0)
+}
@@ -0,0 +1,201 @@
+package policy
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"k8s.io/apimachinery/pkg/runtime/schema"
+)
+
+func TestParsePattern(t *testing.T) {
+	testCases := []struct {
+		name      string
+		pattern   string
+		matches   []string
+		noMatches []string
+	}{
+		{
+			name:      "literal",
+			pattern:   "prod",
+			matches:   []string{"prod"},
+			noMatches: []string{"prod-1", "my-prod"},
+		},
+		{
+			name:      "glob star",
+			pattern:   "prod-*",
+			matches:   []string{"prod-", "prod-eu", "prod-eu/west"},
+			noMatches: []string{"prod", "my-prod-eu"},
+		},
+		{
+			name:      "glob question mark",
+			pattern:   "kube-?",
+			matches:   []string{"kube-1"},
+			noMatches: []string{"kube-10"},
+		},
+		{
+			name:      "glob escapes regexp characters",
+			pattern:   "arn:aws:eks:*:cluster/prod.*",
+			matches:   []string{"arn:aws:eks:eu-west-1:cluster/prod.eu"},
+			noMatches: []string{"arn:aws:eks:eu-west-1:cluster/prodXeu"},
+		},
+		{
+			name:      "regexp",
+			pattern:   "re:(dev|staging)-[0-9]+",
+			matches:   []string{"dev-1", "staging-42"},
+			noMatches: []string{"dev-x", "prod-1", "my-dev-1"},
+		},
+	}
+
+	for _, tc := range testCases {
+		t.Run(tc.name, func(t *testing.T) {
+			p, err := ParsePattern(tc.pattern)
+			assert.NoError(t, err)
+			assert.Equal(t, tc.pattern, p.String())
+			for _, name := range tc.matches {
+				assert.True(t, p.Match(name), name)
+			}
+			for _, name := range tc.noMatches {
+				assert.False(t, p.Match(name), name)
+			}
+		})
+	}
+}
+
+func TestParsePattern_Invalid(t *testing.T) {
+	_, err := ParsePattern("")
+	assert.Error(t, err)
+
+	_, err = ParsePattern("re:(")
+	assert.Error(t, err)
+	assert.Contains(t, err.Error(), "invalid pattern")
+}
+
+func TestFilter(t *testing.T) {
+	testCases := []struct {
+		name          string
+		allow         []string
+		deny          []string
+		input         string
+		expectedError string
+	}{
+		{name: "no rules", input: "anything"},
+		{name: "allowed", allow: []string{"dev-*", "staging"}, input: "dev-1"},
+		{name: "not allowed", allow: []string{"dev-*"}, input: "prod", expectedError: "does not match any allowed pattern"},
+		{name: "denied", deny: []string{"prod*"}, input: "prod-eu", expectedError: `matches denied pattern "prod*"`},
+		{name: "deny wins over allow", allow: []string{"*"}, deny: []string{"re:.*prod.*"}, input: "eu-prod", expectedError: "denied"},
+	}
+
+	for _, tc := range testCases {
+		t.Run(tc.name, func(t *testing.T) {
+			f, err := NewFilter(tc.allow, tc.deny)
+			assert.NoError(t, err)
+			err = f.Check(tc.input)
+			if tc.expectedError != "" {
+				assert.Error(t, err)
+				assert.Contains(t, err.Error(), tc.expectedError)
+				assert.False(t, f.Allowed(tc.input))
+			} else {
+				assert.NoError(t, err)
+				assert.True(t, f.Allowed(tc.input))
+			}
+		})
+	}
+}
+
+func TestFilter_Zero(t *testing.T) {
+	var f *Filter
+	assert.True(t, f.IsZero())
+	assert.True(t, f.Allowed("anything"))
+
+	f, err := NewFilter(nil, []string{"x"})
+	assert.NoError(t, err)
+	assert.False(t, f.IsZero())
+}
+
+func TestNamespacePolicy(t *testing.T) {
+	p, err := NewNamespacePolicy([]NamespaceRule{
+		{Contexts: []string{"prod-*"}, Allowed: []string{"app-*"}},
+		{Contexts: []string{"dev"}, Denied: []string{"kube-*"}},
+		{Denied: []string{"kube-system"}},
+	})
+	assert.NoError(t, err)
+
+	testCases := []struct {
+		context string
+		allowed []string
+		denied  []string
+	}{
+		{context: "prod-eu", allowed: []string{"app-web"}, denied: []string{"kube-system", "default"}},
+		{context: "dev", allowed: []string{"default"}, denied: []string{"kube-public", "kube-system"}},
+		{context: "staging", allowed: []string{"default", "kube-public"}, denied: []string{"kube-system"}},
+	}
+
+	for _, tc := range testCases {
+		t.Run(tc.context, func(t *testing.T) {
+			f := p.ForContext(tc.context)
+			for _, ns := range tc.allowed {
+				assert.True(t, f.Allowed(ns), ns)
+			}
+			for _, ns := range tc.denied {
+				assert.False(t, f.Allowed(ns), ns)
+			}
+		})
+	}
+}
+
+func TestNamespacePolicy_Empty(t *testing.T) {
+	p, err := NewNamespacePolicy(nil)
+	assert.NoError(t, err)
+	assert.Nil(t, p)
+	assert.True(t, p.ForContext("any").IsZero())
+
+	p, err = NewNamespacePolicy([]NamespaceRule{{Contexts: []string{"prod"}, Denied: []string{"kube-system"}}})
+	assert.NoError(t, err)
+	assert.True(t, p.ForContext("dev").IsZero())
+
+	_, err = NewNamespacePolicy([]NamespaceRule{{Contexts: []string{"re:("}}})
+	assert.Error(t, err)
+	assert.Contains(t, err.Error(), "namespace rule 0")
+}
+
+func TestResourcePolicy(t *testing.T) {
+	p, err := NewResourcePolicy(
+		[]string{"secrets", "*.cert-manager.io", "re:.*\\.vault\\..*"},
+		[]string{"configmaps.", "Leases.coordination.k8s.io"},
+	)
+	assert.NoError(t, err)
+
+	secrets := schema.GroupVersionResource{Version: "v1", Resource: "secrets"}
+	configMaps := schema.GroupVersionResource{Version: "v1", Resource: "configmaps"}
+	leases := schema.GroupVersionResource{Group: "coordination.k8s.io", Version: "v1", Resource: "leases"}
+
+	assert.True(t, p.Denied(secrets))
+	assert.True(t, p.Denied(schema.GroupVersionResource{Group: "example.com", Version: "v1", Resource: "secrets"}))
+	assert.True(t, p.Denied(schema.GroupVersionResource{Group: "cert-manager.io", Version: "v1", Resource: "certificates"}))
+	assert.True(t, p.Denied(schema.GroupVersionResource{Group: "secrets.vault.example.com", Version: "v1", Resource: "vaultsecrets"}))
+	assert.False(t, p.Denied(configMaps))
+	assert.False(t, p.Denied(schema.GroupVersionResource{Group: "apps", Version: "v1", Resource: "deployments"}))
+
+	// "configmaps." names the core group only
+	assert.True(t, p.MetadataOnly(configMaps))
+	assert.False(t, p.MetadataOnly(schema.GroupVersionResource{Group: "example.com", Version: "v1", Resource: "configmaps"}))
+	assert.True(t, p.MetadataOnly(leases))
+	assert.False(t, p.MetadataOnly(secrets))
+
+	var nilPolicy *ResourcePolicy
+	assert.False(t, nilPolicy.Denied(secrets))
+	assert.False(t, nilPolicy.MetadataOnly(secrets))
+
+	_, err = NewResourcePolicy([]string{"re:("}, nil)
+	assert.Error(t, err)
+}
+
+func TestResourcePolicy_RegexpClasses(t *testing.T) {
+	// \S and \D must keep their meaning, and the match ignores case like resource names do
+	p, err := NewResourcePolicy([]string{`re:\S+\.Vault\.\D+`}, nil)
+	assert.NoError(t, err)
+
+	assert.True(t, p.Denied(schema.GroupVersionResource{Group: "secrets.vault.example.com", Version: "v1", Resource: "vaultsecrets"}))
+	assert.False(t, p.Denied(schema.GroupVersionResource{Group: "secrets.vault.v1", Version: "v1", Resource: "vaultsecrets"}))
+	assert.False(t, p.Denied(schema.GroupVersionResource{Group: "apps", Version: "v1", Resource: "deployments"}))
+}
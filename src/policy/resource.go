@@ -0,0 +1,111 @@
+package policy
+
+import (
+	"fmt"
+	"strings"
+
+	"k8s.io/apimachinery/pkg/runtime/schema"
+)
+
+// DefaultDeniedResources are blocked unless the configuration says otherwise.
+var DefaultDeniedResources = []string{"secrets"}
+
+// ResourcePolicy decides which API resources tools may read and which of them
+// may only be read as metadata.
+// Resources are written like kubectl resource arguments: "resource" or "resource.group",
+// e.g. "secrets" or "certificates.cert-manager.io". Both parts may be globs or the entry may be a
+// "re:" regular expression matched against "resource.group". Without a group, the resource
+// matches in every API group; "resource." matches the core group only. The nil policy allows everything.
+type ResourcePolicy struct {
+	denied       []resourcePattern
+	metadataOnly []resourcePattern
+}
+
+type resourcePattern struct {
+	resource Pattern
+	// group is nil when the entry has no group and matches every group
+	group *Pattern
+	// coreOnly is set for "resource." entries, which match the core group only
+	coreOnly bool
+	// full matches "resource.group" for "re:" entries
+	full *Pattern
+}
+
+// NewResourcePolicy compiles the denied and metadata-only resource lists.
+func NewResourcePolicy(denied, metadataOnly []string) (*ResourcePolicy, error) {
+	deniedPatterns, err := parseResourcePatterns(denied)
+	if err != nil {
+		return nil, err
+	}
+	metadataOnlyPatterns, err := parseResourcePatterns(metadataOnly)
+	if err != nil {
+		return nil, err
+	}
+	return &ResourcePolicy{denied: deniedPatterns, metadataOnly: metadataOnlyPatterns}, nil
+}
+
+func parseResourcePatterns(entries []string) ([]resourcePattern, error) {
+	patterns := make([]resourcePattern, 0, len(entries))
+	for _, entry := range entries {
+		if expr, isRegexp := strings.CutPrefix(entry, regexpPrefix); isRegexp {
+			// Lower-casing a regular expression would change classes such as \S, so it is matched
+			// case-insensitively instead
+			full, err := ParsePattern(regexpPrefix + "(?i)" + expr)
+			if err != nil {
+				return nil, fmt.Errorf("invalid resource %q: %w", entry, err)
+			}
+			patterns = append(patterns, resourcePattern{full: &full})
+			continue
+		}
+
+		resource, group, hasGroup := strings.Cut(strings.ToLower(entry), ".")
+		p := resourcePattern{}
+		var err error
+		if p.resource, err = ParsePattern(resource); err != nil {
+			return nil, fmt.Errorf("invalid resource %q: %w", entry, err)
+		}
+		if hasGroup && group == "" {
+			p.coreOnly = true
+		} else if hasGroup {
+			groupPattern, err := ParsePattern(group)
+			if err != nil {
+				return nil, fmt.Errorf("invalid resource %q: %w", entry, err)
+			}
+			p.group = &groupPattern
+		}
+		patterns = append(patterns, p)
+	}
+	return patterns, nil
+}
+
+func (p resourcePattern) match(gvr schema.GroupVersionResource) bool {
+	if p.full != nil {
+		return p.full.Match(strings.TrimSuffix(gvr.Resource+"."+gvr.Group, "."))
+	}
+	if !p.resource.Match(gvr.Resource) {
+		return false
+	}
+	if p.coreOnly {
+		return gvr.Group == ""
+	}
+	return p.group == nil || p.group.Match(gvr.Group)
+}
+
+func matchAny(patterns []resourcePattern, gvr schema.GroupVersionResource) bool {
+	for _, p := range patterns {
+		if p.match(gvr) {
+			return true
+		}
+	}
+	return false
+}
+
+// Denied reports whether the resource may not be read at all.
+func (p *ResourcePolicy) Denied(gvr schema.GroupVersionResource) bool {
+	return p != nil && matchAny(p.denied, gvr)
+}
+
+// MetadataOnly reports whether only the metadata of the resource may be returned.
+func (p *ResourcePolicy) MetadataOnly(gvr schema.GroupVersionResource) bool {
+	return p != nil && matchAny(p.metadataOnly, gvr)
+}
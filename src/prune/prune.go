@@ -0,0 +1,62 @@
+// Package prune removes noisy fields, such as managedFields, from the objects returned by tools.
+package prune
+
+import "github.com/kkb0318/kubernetes-mcp/src/fieldpath"
+
+// DefaultPaths returns the built-in paths: server-side apply's managedFields and kubectl's
+// last-applied-configuration annotation, which often make up most of an object.
+func DefaultPaths() []string {
+	return []string{
+		"metadata.managedFields",
+		`metadata.annotations.kubectl\.kubernetes\.io/last-applied-configuration`,
+	}
+}
+
+// Pruner removes the fields at a set of paths. The nil Pruner removes nothing.
+type Pruner struct {
+	paths fieldpath.Patterns
+}
+
+// New compiles paths, which use the syntax described in fieldpath.Compile.
+func New(paths []string) (*Pruner, error) {
+	patterns, err := fieldpath.Compile(paths)
+	if err != nil {
+		return nil, err
+	}
+	return &Pruner{paths: patterns}, nil
+}
+
+// Object removes the matching fields from a decoded object, such as an unstructured object, in
+// place. It returns the number of removed fields.
+func (p *Pruner) Object(obj map[string]any) int {
+	if p == nil {
+		return 0
+	}
+	return p.walk(obj, nil)
+}
+
+func (p *Pruner) walk(v any, path []string) int {
+	count := 0
+	switch value := v.(type) {
+	case map[string]any:
+		for k, child := range value {
+			childPath := append(path, k)
+			if p.paths.Match(childPath) {
+				delete(value, k)
+				count++
+				continue
+			}
+			n := p.walk(child, childPath)
+			count += n
+			// Drop annotations emptied by pruning
+			if annotations, ok := child.(map[string]any); ok && k == "annotations" && n > 0 && len(annotations) == 0 {
+				delete(value, k)
+			}
+		}
+	case []any:
+		for _, child := range value {
+			count += p.walk(child, path)
+		}
+	}
+	return count
+}
@@ -0,0 +1,80 @@
+package prune
+
+import (
+	"encoding/json"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func TestPruner_Object(t *testing.T) {
+	testCases := []struct {
+		name          string
+		paths         []string
+		input         string
+		expected      string
+		expectedCount int
+	}{
+		{
+			name:          "managed fields and last applied configuration",
+			paths:         DefaultPaths(),
+			input:         `{"metadata":{"name":"web","managedFields":[{"manager":"kubectl"}],"annotations":{"kubectl.kubernetes.io/last-applied-configuration":"{}","team":"a"}}}`,
+			expected:      `{"metadata":{"annotations":{"team":"a"},"name":"web"}}`,
+			expectedCount: 2,
+		},
+		{
+			name:          "emptied annotations are dropped",
+			paths:         DefaultPaths(),
+			input:         `{"metadata":{"name":"web","annotations":{"kubectl.kubernetes.io/last-applied-configuration":"{}"}}}`,
+			expected:      `{"metadata":{"name":"web"}}`,
+			expectedCount: 1,
+		},
+		{
+			name:          "other empty annotations are kept",
+			paths:         DefaultPaths(),
+			input:         `{"metadata":{"name":"web","annotations":{},"managedFields":[]},"spec":{"template":{"metadata":{"annotations":{}}}}}`,
+			expected:      `{"metadata":{"annotations":{},"name":"web"},"spec":{"template":{"metadata":{"annotations":{}}}}}`,
+			expectedCount: 1,
+		},
+		{
+			name:          "nested objects and arrays",
+			paths:         []string{"metadata.generation", "status.conditions.lastProbeTime"},
+			input:         `{"spec":{"template":{"metadata":{"generation":1}}},"status":{"conditions":[{"type":"Ready","lastProbeTime":null}]}}`,
+			expected:      `{"spec":{"template":{"metadata":{}}},"status":{"conditions":[{"type":"Ready"}]}}`,
+			expectedCount: 2,
+		},
+		{
+			name:     "nothing to prune",
+			paths:    DefaultPaths(),
+			input:    `{"metadata":{"name":"web"}}`,
+			expected: `{"metadata":{"name":"web"}}`,
+		},
+	}
+
+	for _, tc := range testCases {
+		t.Run(tc.name, func(t *testing.T) {
+			p, err := New(tc.paths)
+			assert.NoError(t, err)
+			var obj map[string]any
+			assert.NoError(t, json.Unmarshal([]byte(tc.input), &obj))
+
+			count := p.Object(obj)
+			actual, err := json.Marshal(obj)
+			assert.NoError(t, err)
+			assert.Equal(t, tc.expected, string(actual))
+			assert.Equal(t, tc.expectedCount, count)
+		})
+	}
+}
+
+func TestPruner_Nil(t *testing.T) {
+	var p *Pruner
+	obj := map[string]any{"metadata": map[string]any{"managedFields": []any{}}}
+	assert.Equal(t, 0, p.Object(obj))
+	assert.Contains(t, obj["metadata"], "managedFields")
+}
+
+func TestNew_InvalidPath(t *testing.T) {
+	_, err := New([]string{""})
+	assert.Error(t, err)
+}
@@ -0,0 +1,234 @@
+// Package redact removes sensitive values, such as passwords and tokens, from tool output.
+package redact
+
+import (
+	"bytes"
+	"encoding/json"
+	"fmt"
+	"regexp"
+	"strings"
+
+	"github.com/kkb0318/kubernetes-mcp/src/fieldpath"
+)
+
+// Placeholder replaces every redacted value.
+const Placeholder = "[REDACTED]"
+
+// secretGroup names the part of a value pattern match to replace. Without it the whole match is replaced.
+const secretGroup = "secret"
+
+// Rules configures what is redacted.
+type Rules struct {
+	// Paths are dot-separated object paths whose values are always redacted, whatever their type.
+	// A path matches the end of the path to a value, so "annotations.foo" matches
+	// "metadata.annotations.foo" too. Array indexes are not part of paths, "*" matches any key and
+	// a literal dot in a key is escaped as "\.".
+	Paths []string `json:"paths,omitempty"`
+	// KeyPatterns are regular expressions matched against object keys. String values under a
+	// matching key are redacted, as are the values of name/value pairs, such as container
+	// environment variables, whose name matches.
+	KeyPatterns []string `json:"keyPatterns,omitempty"`
+	// ValuePatterns are regular expressions for credentials found inside any string, such as log
+	// lines. If a pattern has a group named "secret", only that group is replaced.
+	ValuePatterns []string `json:"valuePatterns,omitempty"`
+}
+
+// DefaultRules returns the built-in rules, covering kubectl's last-applied-configuration annotation,
+// common credential key names and well-known token formats.
+func DefaultRules() Rules {
+	return Rules{
+		Paths: []string{
+			`annotations.kubectl\.kubernetes\.io/last-applied-configuration`,
+		},
+		KeyPatterns: []string{
+			`(?i)(password|passwd|pwd|passphrase|secret|token|api[-_.]?key|access[-_.]?key|private[-_.]?key|credentials?)$`,
+		},
+		ValuePatterns: []string{
+			// Authorization headers
+			`(?i)\bbearer\s+(?P<secret>[A-Za-z0-9\-._~+/]{8,}=*)`,
+			`(?i)\bbasic\s+(?P<secret>[A-Za-z0-9+/]{8,}=*)`,
+			// JSON Web Tokens
+			`\beyJ[A-Za-z0-9_-]{5,}\.eyJ[A-Za-z0-9_-]{5,}\.[A-Za-z0-9_-]*`,
+			// AWS access key IDs
+			`\b(?:AKIA|ASIA)[0-9A-Z]{16}\b`,
+			// GitHub and Slack tokens
+			`\bgh[pousr]_[A-Za-z0-9]{36,}\b`,
+			`\bxox[abprs]-[A-Za-z0-9-]{10,}`,
+			// PEM private keys
+			`-----BEGIN [A-Z ]*PRIVATE KEY-----[\s\S]*?-----END [A-Z ]*PRIVATE KEY-----`,
+			// password=..., token: ... and similar assignments
+			`(?i)\b(?:password|passwd|pwd|secret|token|api[-_]?key|access[-_]?key)["']?\s*[=:]\s*["']?(?P<secret>[^\s"'&,;]+)`,
+			// Credentials in URLs
+			`[a-zA-Z][a-zA-Z0-9+.-]*://[^\s:/@]+:(?P<secret>[^\s/@]+)@`,
+		},
+	}
+}
+
+// Redactor applies redaction rules. The nil Redactor redacts nothing.
+type Redactor struct {
+	paths         fieldpath.Patterns
+	keyPatterns   []*regexp.Regexp
+	valuePatterns []*regexp.Regexp
+}
+
+// New compiles the rules.
+func New(rules Rules) (*Redactor, error) {
+	paths, err := fieldpath.Compile(rules.Paths)
+	if err != nil {
+		return nil, err
+	}
+	r := &Redactor{paths: paths}
+	for _, pattern := range rules.KeyPatterns {
+		re, err := regexp.Compile(pattern)
+		if err != nil {
+			return nil, fmt.Errorf("invalid key pattern %q: %w", pattern, err)
+		}
+		r.keyPatterns = append(r.keyPatterns, re)
+	}
+	for _, pattern := range rules.ValuePatterns {
+		re, err := regexp.Compile(pattern)
+		if err != nil {
+			return nil, fmt.Errorf("invalid value pattern %q: %w", pattern, err)
+		}
+		r.valuePatterns = append(r.valuePatterns, re)
+	}
+	return r, nil
+}
+
+// JSON redacts a JSON document. The document is returned unchanged if nothing was redacted.
+func (r *Redactor) JSON(data []byte) ([]byte, int, error) {
+	if r == nil {
+		return data, 0, nil
+	}
+	decoder := json.NewDecoder(bytes.NewReader(data))
+	decoder.UseNumber()
+	var v any
+	if err := decoder.Decode(&v); err != nil {
+		return nil, 0, fmt.Errorf("failed to decode output for redaction: %w", err)
+	}
+	v, count := r.Value(v)
+	if count == 0 {
+		return data, 0, nil
+	}
+	var buf bytes.Buffer
+	encoder := json.NewEncoder(&buf)
+	encoder.SetEscapeHTML(false)
+	if err := encoder.Encode(v); err != nil {
+		return nil, 0, fmt.Errorf("failed to encode redacted output: %w", err)
+	}
+	return bytes.TrimSuffix(buf.Bytes(), []byte("\n")), count, nil
+}
+
+// Value redacts a decoded JSON value, such as the result of json.Unmarshal into any or an
+// unstructured object. Maps and slices are modified in place. It returns the redacted value and
+// the number of redactions.
+func (r *Redactor) Value(v any) (any, int) {
+	if r == nil {
+		return v, 0
+	}
+	return r.walk(v, nil)
+}
+
+func (r *Redactor) walk(v any, path []string) (any, int) {
+	switch value := v.(type) {
+	case map[string]any:
+		count := 0
+		for k, child := range value {
+			childPath := append(path, k)
+			if r.paths.Match(childPath) {
+				value[k] = Placeholder
+				count++
+				continue
+			}
+			if s, ok := child.(string); ok && r.matchKey(k) {
+				if s != "" && s != Placeholder {
+					value[k] = Placeholder
+					count++
+				}
+				continue
+			}
+			redacted, n := r.walk(child, childPath)
+			value[k] = redacted
+			count += n
+		}
+		count += r.redactNameValuePair(value)
+		return value, count
+	case []any:
+		count := 0
+		for i, child := range value {
+			redacted, n := r.walk(child, path)
+			value[i] = redacted
+			count += n
+		}
+		return value, count
+	case string:
+		return r.String(value)
+	default:
+		return v, 0
+	}
+}
+
+// redactNameValuePair redacts the value of {"name": ..., "value": ...} entries, such as container
+// environment variables, whose name matches a key pattern.
+func (r *Redactor) redactNameValuePair(m map[string]any) int {
+	name, ok := m["name"].(string)
+	if !ok || !r.matchKey(name) {
+		return 0
+	}
+	value, ok := m["value"].(string)
+	if !ok || value == "" || value == Placeholder {
+		return 0
+	}
+	m["value"] = Placeholder
+	return 1
+}
+
+func (r *Redactor) matchKey(key string) bool {
+	for _, re := range r.keyPatterns {
+		if re.MatchString(key) {
+			return true
+		}
+	}
+	return false
+}
+
+// String redacts credentials found in free text, such as log output.
+func (r *Redactor) String(s string) (string, int) {
+	if r == nil {
+		return s, 0
+	}
+	count := 0
+	for _, re := range r.valuePatterns {
+		group := re.SubexpIndex(secretGroup)
+		s = replaceAll(re, s, group, &count)
+	}
+	return s, count
+}
+
+// replaceAll replaces every match of re in s, or only the given group of each match when group > 0.
+func replaceAll(re *regexp.Regexp, s string, group int, count *int) string {
+	matches := re.FindAllStringSubmatchIndex(s, -1)
+	if len(matches) == 0 {
+		return s
+	}
+	var b strings.Builder
+	last := 0
+	for _, m := range matches {
+		start, end := m[0], m[1]
+		if group > 0 {
+			start, end = m[2*group], m[2*group+1]
+			if start < 0 {
+				continue
+			}
+		}
+		if s[start:end] == Placeholder {
+			continue
+		}
+		b.WriteString(s[last:start])
+		b.WriteString(Placeholder)
+		last = end
+		*count++
+	}
+	b.WriteString(s[last:])
+	return b.String()
+}
@@ -1,11 +1,14 @@
 package tools
 
 import (
+	"context"
+
 	"k8s.io/apimachinery/pkg/api/meta"
 	"k8s.io/apimachinery/pkg/runtime/schema"
 	"k8s.io/client-go/discovery"
 	"k8s.io/client-go/dynamic"
 	"k8s.io/client-go/kubernetes"
+	"k8s.io/client-go/rest"
 )
 
 type Client interface {
@@ -14,11 +17,20 @@ type Client interface {
 	RESTMapper() (meta.RESTMapper, error)
 	Clientset() (*kubernetes.Clientset, error)
 	ResourceInterface(gvr schema.GroupVersionResource, namespaced bool, ns string) (dynamic.ResourceInterface, error)
+	// RESTClient returns a client for raw API requests, such as lists rendered as server-side tables.
+	RESTClient() (rest.Interface, error)
+}
+
+// DiscoveryInvalidator is implemented by clients that cache discovery results, so that a kind that
+// is not found can be looked up again after, for example, a CRD was installed.
+type DiscoveryInvalidator interface {
+	InvalidateDiscovery()
 }
 
 // MultiClusterClientInterface for managing multiple cluster connections.
 type MultiClusterClientInterface interface {
-	GetClient(context string) (Client, error)
+	// GetClient returns a client for the named kubeconfig context acting on behalf of the caller in ctx.
+	GetClient(ctx context.Context, contextName string) (Client, error)
 	GetDefaultContext() string
-	ListContexts() ([]string, error)
+	ListContexts() ([]ContextInfo, error)
 }
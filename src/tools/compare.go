@@ -0,0 +1,426 @@
+package tools
+
+import (
+	"context"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"reflect"
+	"slices"
+	"strconv"
+	"strings"
+	"sync"
+
+	"github.com/mark3labs/mcp-go/mcp"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/apis/meta/v1/unstructured"
+	"k8s.io/apimachinery/pkg/runtime"
+)
+
+// Kinds of difference reported by compare_resources, from the left object to the right one.
+const (
+	changeAdded   = "added"
+	changeRemoved = "removed"
+	changeChanged = "changed"
+)
+
+// compareIgnoredMetadata are the metadata fields populated by the API server, which differ between
+// any two objects and are left out of comparisons.
+var compareIgnoredMetadata = []string{"uid", "resourceVersion", "generation", "creationTimestamp", "managedFields", "selfLink"}
+
+// CompareResourcesInput represents the input parameters for comparing two Kubernetes resources.
+type CompareResourcesInput struct {
+	Kind       string   `json:"kind"`
+	Name       string   `json:"name"`
+	Namespace  string   `json:"namespace,omitempty"`
+	Context    string   `json:"context,omitempty"`
+	Contexts   []string `json:"contexts,omitempty"`
+	Namespaces []string `json:"namespaces,omitempty"`
+	Output     string   `json:"output,omitempty"`
+}
+
+// CompareSide identifies where one of the compared objects was read from.
+type CompareSide struct {
+	Context   string `json:"context"`
+	Namespace string `json:"namespace,omitempty"`
+}
+
+func (s CompareSide) String() string {
+	if s.Namespace == "" {
+		return s.Context
+	}
+	return s.Context + "/" + s.Namespace
+}
+
+// CompareResult is the result of compare_resources: the differences in spec, labels, annotations
+// and other top-level fields, such as the data of a ConfigMap, between the left and right objects.
+type CompareResult struct {
+	Kind        string       `json:"kind"`
+	Name        string       `json:"name"`
+	Left        CompareSide  `json:"left"`
+	Right       CompareSide  `json:"right"`
+	Identical   bool         `json:"identical"`
+	Differences []Difference `json:"differences"`
+}
+
+// Difference is a field whose value differs between the compared objects. Path is a JSONPath
+// expression that can be passed to the fields argument of describe_resource.
+type Difference struct {
+	Path   string `json:"path"`
+	Change string `json:"change"`
+	Left   any    `json:"left,omitempty"`
+	Right  any    `json:"right,omitempty"`
+}
+
+// table prints one row per difference, with the values as JSON.
+func (r CompareResult) table() textTable {
+	t := textTable{title: fmt.Sprintf("%s %s: %s vs %s", r.Kind, r.Name, r.Left, r.Right)}
+	if r.Identical {
+		t.footer = []string{"no differences"}
+		return t
+	}
+	t.headers = []string{"Path", "Change", "Left", "Right"}
+	for _, diff := range r.Differences {
+		t.rows = append(t.rows, []string{diff.Path, diff.Change, compareCell(diff.Left), compareCell(diff.Right)})
+	}
+	t.footer = []string{fmt.Sprintf("%d difference(s)", len(r.Differences))}
+	return t
+}
+
+// compareCell formats a compared value for a table cell.
+func compareCell(value any) string {
+	switch v := value.(type) {
+	case nil:
+		return "<none>"
+	case string:
+		return v
+	}
+	out, _ := json.Marshal(value)
+	return string(out)
+}
+
+// CompareTool compares a Kubernetes resource across two contexts or two namespaces.
+type CompareTool struct {
+	multiClient MultiClusterClientInterface
+	opts        Options
+	describe    *DescribeTool
+}
+
+// NewCompareTool creates a new CompareTool instance with the provided MultiClusterClient and options.
+func NewCompareTool(multiClient MultiClusterClientInterface, opts Options) *CompareTool {
+	return &CompareTool{multiClient: multiClient, opts: opts, describe: NewDescribeTool(multiClient, opts)}
+}
+
+// Tool returns the MCP tool definition for comparing Kubernetes resources.
+func (c *CompareTool) Tool() mcp.Tool {
+	return mcp.NewTool("compare_resources",
+		mcp.WithDescription("Compare a Kubernetes resource between two contexts (e.g. staging and production) or two namespaces, returning the differences in spec, labels, annotations and other fields. Fields populated by the server, such as uid, resourceVersion, managedFields and status, are ignored"),
+		mcp.WithString("kind",
+			mcp.Required(),
+			mcp.Description("Kind of the Kubernetes resource, e.g., Deployment, ConfigMap, or any CRD. Qualify it with its API group as kind.group or group/version/kind when it exists in several groups"),
+		),
+		mcp.WithString("name",
+			mcp.Required(),
+			mcp.Description("Name of the resource to compare"),
+		),
+		mcp.WithString("namespace",
+			mcp.Description("Kubernetes namespace of the resource in both contexts (leave empty for cluster-scoped resources)"),
+		),
+		mcp.WithArray("contexts",
+			mcp.Description("The two Kubernetes contexts to compare the resource between, e.g. ['staging', 'production']"),
+			mcp.Items(map[string]any{"type": "string"}),
+		),
+		mcp.WithArray("namespaces",
+			mcp.Description("The two namespaces to compare the resource between in one context, e.g. ['team-a', 'team-b']"),
+			mcp.Items(map[string]any{"type": "string"}),
+		),
+		mcp.WithString("context",
+			mcp.Description("Kubernetes context name from kubeconfig to use when comparing namespaces (leave empty for current context)"),
+		),
+		mcp.WithString("output",
+			mcp.Description("Output format: 'json' (default), 'yaml' or 'table'"),
+			mcp.Enum(outputJSON, outputYAML, outputTable),
+		),
+	)
+}
+
+// Handler processes requests to compare a Kubernetes resource between two contexts or namespaces.
+func (c *CompareTool) Handler(ctx context.Context, req mcp.CallToolRequest) (*mcp.CallToolResult, error) {
+	input, err := parseAndValidateCompareParams(req.GetArguments())
+	if err != nil {
+		return nil, err
+	}
+
+	left, right := c.sides(input)
+	resources := make([]*unstructured.Unstructured, 2)
+	errs := make([]error, 2)
+	var wg sync.WaitGroup
+	for i, side := range []CompareSide{left, right} {
+		wg.Add(1)
+		go func() {
+			defer wg.Done()
+			resources[i], errs[i] = c.getResource(ctx, input, side)
+		}()
+	}
+	wg.Wait()
+	if err := errors.Join(errs...); err != nil {
+		return nil, err
+	}
+
+	// Compare the objects as stored, so that sensitive values that differ are still reported, and
+	// take the reported values from redacted copies, which keep the keys that identify them
+	objects := make([]map[string]any, 2)
+	redacted := make([]map[string]any, 2)
+	for i, resource := range resources {
+		objects[i] = c.normalize(resource)
+		redacted[i] = runtime.DeepCopyJSON(objects[i])
+		c.opts.redactObject(redacted[i])
+	}
+
+	result := CompareResult{
+		Kind:        resources[0].GetKind(),
+		Name:        input.Name,
+		Left:        left,
+		Right:       right,
+		Differences: []Difference{},
+	}
+	redactions := diffRedacted("", objects[0], objects[1], redacted[0], redacted[1], &result.Differences)
+	result.Identical = len(result.Differences) == 0
+
+	return c.opts.formatOutput(result, input.Output, redactions)
+}
+
+// sides returns the context and namespace of the left and right objects.
+func (c *CompareTool) sides(input *CompareResourcesInput) (CompareSide, CompareSide) {
+	if len(input.Contexts) == 2 {
+		return CompareSide{Context: input.Contexts[0], Namespace: input.Namespace},
+			CompareSide{Context: input.Contexts[1], Namespace: input.Namespace}
+	}
+	contextName := input.Context
+	if contextName == "" {
+		contextName = c.multiClient.GetDefaultContext()
+	}
+	return CompareSide{Context: contextName, Namespace: input.Namespaces[0]},
+		CompareSide{Context: contextName, Namespace: input.Namespaces[1]}
+}
+
+// getResource reads the resource on one side through describe_resource, with the same policies.
+func (c *CompareTool) getResource(ctx context.Context, input *CompareResourcesInput, side CompareSide) (*unstructured.Unstructured, error) {
+	client, err := c.multiClient.GetClient(ctx, side.Context)
+	if err != nil {
+		return nil, fmt.Errorf("failed to get client for context '%s': %w", side.Context, err)
+	}
+	gvrMatch, err := resolveKind(client, input.Kind)
+	if err != nil {
+		return nil, fmt.Errorf("failed to resolve kind in %s: %w", side, err)
+	}
+	resource, err := c.describe.getResource(ctx, client, gvrMatch, &DescribeResourceInput{
+		Context:   side.Context,
+		Kind:      input.Kind,
+		Name:      input.Name,
+		Namespace: side.Namespace,
+	})
+	if err != nil {
+		return nil, fmt.Errorf("failed to read %s: %w", side, err)
+	}
+	return resource, nil
+}
+
+// normalize returns the fields of resource that describe its desired state: labels, annotations and
+// every top-level field but apiVersion, kind, metadata and status.
+func (c *CompareTool) normalize(resource *unstructured.Unstructured) map[string]any {
+	obj := resource.DeepCopy().Object
+	c.opts.Pruner.Object(obj)
+	for _, field := range compareIgnoredMetadata {
+		unstructured.RemoveNestedField(obj, "metadata", field)
+	}
+
+	normalized := map[string]any{}
+	metadata := map[string]any{}
+	for _, field := range []string{"labels", "annotations"} {
+		if value, found, _ := unstructured.NestedFieldNoCopy(obj, "metadata", field); found && value != nil {
+			metadata[field] = value
+		}
+	}
+	if len(metadata) > 0 {
+		normalized["metadata"] = metadata
+	}
+	for field, value := range obj {
+		switch field {
+		case "apiVersion", "kind", "metadata", "status":
+			continue
+		}
+		normalized[field] = value
+	}
+	return normalized
+}
+
+// diffValues appends the differences between left and right, found at path, to diffs. Maps are
+// compared key by key and lists element by element.
+func diffValues(path string, left, right any, diffs *[]Difference) {
+	diffRedacted(path, left, right, left, right, diffs)
+}
+
+// diffRedacted is diffValues for objects whose differences are reported with the values found at
+// the same place in redactedLeft and redactedRight. A value redacted as a whole, such as a map
+// under a redacted path, stands in for everything below it. It returns the number of reported
+// values that were redacted.
+func diffRedacted(path string, left, right, redactedLeft, redactedRight any, diffs *[]Difference) int {
+	count := 0
+	report := func(diff Difference, redactedLeft, redactedRight any) {
+		if diff.Left != nil && !reflect.DeepEqual(diff.Left, redactedLeft) {
+			diff.Left = redactedLeft
+			count++
+		}
+		if diff.Right != nil && !reflect.DeepEqual(diff.Right, redactedRight) {
+			diff.Right = redactedRight
+			count++
+		}
+		*diffs = append(*diffs, diff)
+	}
+
+	leftMap, leftIsMap := left.(map[string]any)
+	rightMap, rightIsMap := right.(map[string]any)
+	if leftIsMap && rightIsMap {
+		keys := make([]string, 0, len(leftMap)+len(rightMap))
+		for key := range leftMap {
+			keys = append(keys, key)
+		}
+		for key := range rightMap {
+			if _, found := leftMap[key]; !found {
+				keys = append(keys, key)
+			}
+		}
+		slices.Sort(keys)
+		for _, key := range keys {
+			childPath := path + "." + strings.ReplaceAll(key, ".", `\.`)
+			leftValue, inLeft := leftMap[key]
+			rightValue, inRight := rightMap[key]
+			redactedLeftValue, redactedRightValue := redactedChild(redactedLeft, key), redactedChild(redactedRight, key)
+			switch {
+			case !inLeft:
+				report(Difference{Path: childPath, Change: changeAdded, Right: rightValue}, nil, redactedRightValue)
+			case !inRight:
+				report(Difference{Path: childPath, Change: changeRemoved, Left: leftValue}, redactedLeftValue, nil)
+			default:
+				count += diffRedacted(childPath, leftValue, rightValue, redactedLeftValue, redactedRightValue, diffs)
+			}
+		}
+		return count
+	}
+
+	leftList, leftIsList := left.([]any)
+	rightList, rightIsList := right.([]any)
+	if leftIsList && rightIsList {
+		for i := range max(len(leftList), len(rightList)) {
+			childPath := path + "[" + strconv.Itoa(i) + "]"
+			redactedLeftValue, redactedRightValue := redactedElement(redactedLeft, i), redactedElement(redactedRight, i)
+			switch {
+			case i >= len(leftList):
+				report(Difference{Path: childPath, Change: changeAdded, Right: rightList[i]}, nil, redactedRightValue)
+			case i >= len(rightList):
+				report(Difference{Path: childPath, Change: changeRemoved, Left: leftList[i]}, redactedLeftValue, nil)
+			default:
+				count += diffRedacted(childPath, leftList[i], rightList[i], redactedLeftValue, redactedRightValue, diffs)
+			}
+		}
+		return count
+	}
+
+	if !reflect.DeepEqual(left, right) {
+		report(Difference{Path: path, Change: changeChanged, Left: left, Right: right}, redactedLeft, redactedRight)
+	}
+	return count
+}
+
+// redactedChild returns the value under key in a redacted map, or the redacted value itself when
+// it replaced the whole map.
+func redactedChild(redacted any, key string) any {
+	if m, ok := redacted.(map[string]any); ok {
+		return m[key]
+	}
+	return redacted
+}
+
+// redactedElement returns element i of a redacted list, or the redacted value itself when it
+// replaced the whole list.
+func redactedElement(redacted any, i int) any {
+	if list, ok := redacted.([]any); ok && i < len(list) {
+		return list[i]
+	}
+	return redacted
+}
+
+// parseAndValidateCompareParams validates and extracts parameters from request arguments.
+func parseAndValidateCompareParams(args map[string]any) (*CompareResourcesInput, error) {
+	input := &CompareResourcesInput{}
+
+	if kindVal, ok := args["kind"].(string); ok && kindVal != "" {
+		input.Kind = kindVal
+	} else {
+		return nil, errors.New("kind must be provided and be a string")
+	}
+
+	if nameVal, ok := args["name"].(string); ok && nameVal != "" {
+		input.Name = nameVal
+	} else {
+		return nil, errors.New("name must be provided and be a string")
+	}
+
+	if ns, ok := args["namespace"].(string); ok {
+		input.Namespace = ns
+	}
+	if input.Namespace == "" {
+		input.Namespace = metav1.NamespaceAll
+	}
+
+	if context, ok := args["context"].(string); ok {
+		input.Context = context
+	}
+
+	// Either two contexts or two namespaces
+	contexts, err := parseStringList(args, "contexts")
+	if err != nil {
+		return nil, err
+	}
+	namespaces, err := parseStringList(args, "namespaces")
+	if err != nil {
+		return nil, err
+	}
+	switch {
+	case len(contexts) > 0 && len(namespaces) > 0:
+		return nil, errors.New("contexts cannot be combined with namespaces")
+	case len(contexts) > 0:
+		if len(contexts) != 2 || slices.Contains(contexts, allContexts) {
+			return nil, errors.New("contexts must name two different contexts")
+		}
+		if input.Context != "" {
+			return nil, errors.New("context cannot be combined with contexts")
+		}
+		input.Contexts = contexts
+	case len(namespaces) > 0:
+		if len(namespaces) != 2 {
+			return nil, errors.New("namespaces must name two different namespaces")
+		}
+		if input.Namespace != "" {
+			return nil, errors.New("namespace cannot be combined with namespaces")
+		}
+		input.Namespaces = namespaces
+	default:
+		return nil, errors.New("either contexts or namespaces must be provided")
+	}
+
+	output, err := parseOutput(args)
+	if err != nil {
+		return nil, err
+	}
+	if output == outputName {
+		return nil, fmt.Errorf("output '%s' is not supported by compare_resources", output)
+	}
+	input.Output = output
+
+	return input, nil
+}
+
+// Compile-time verification that CompareTool implements Tools interface
+var _ Tools = (*CompareTool)(nil)
@@ -0,0 +1,198 @@
+package tools
+
+import (
+	"context"
+	"testing"
+
+	"github.com/kkb0318/kubernetes-mcp/src/policy"
+	"github.com/mark3labs/mcp-go/mcp"
+	"github.com/stretchr/testify/assert"
+	"k8s.io/apimachinery/pkg/apis/meta/v1/unstructured"
+	"k8s.io/apimachinery/pkg/runtime"
+	"k8s.io/apimachinery/pkg/types"
+)
+
+// newCompareTestDeployment returns a deployment with server-populated fields that differ between
+// any two objects
+func newCompareTestDeployment(namespace, uid string, replicas int64, image string, labels map[string]string) *unstructured.Unstructured {
+	obj := newUnstructured("apps/v1", "Deployment", namespace, "web")
+	obj.SetUID(types.UID("uid-" + uid))
+	obj.SetResourceVersion(uid)
+	obj.SetLabels(labels)
+	obj.SetAnnotations(map[string]string{
+		"kubectl.kubernetes.io/last-applied-configuration": "{}",
+		"deployment.kubernetes.io/revision":                uid,
+	})
+	obj.Object["metadata"].(map[string]any)["managedFields"] = []any{map[string]any{"manager": uid}}
+	obj.Object["spec"] = map[string]any{
+		"replicas": replicas,
+		"template": map[string]any{"spec": map[string]any{
+			"containers": []any{map[string]any{"name": "web", "image": image}},
+		}},
+	}
+	obj.Object["status"] = map[string]any{"readyReplicas": replicas}
+	return obj
+}
+
+func TestCompareTool_Contexts(t *testing.T) {
+	multiClient := &ContextsMultiClusterClient{clients: map[string]Client{
+		"staging": FakeObjectsClient{resources: workloadTestResources, objects: []runtime.Object{
+			newCompareTestDeployment("apps", "1", 1, "web:1.1", map[string]string{"app": "web", "canary": "true"}),
+		}},
+		"production": FakeObjectsClient{resources: workloadTestResources, objects: []runtime.Object{
+			newCompareTestDeployment("apps", "2", 3, "web:1.0", map[string]string{"app": "web", "app.kubernetes.io/version": "1.0"}),
+		}},
+	}}
+	tool := NewCompareTool(multiClient, DefaultOptions())
+
+	result, err := tool.Handler(context.Background(), mcp.CallToolRequest{Params: mcp.CallToolParams{Arguments: map[string]any{
+		"kind":      "Deployment",
+		"name":      "web",
+		"namespace": "apps",
+		"contexts":  []any{"staging", "production"},
+	}}})
+	assert.NoError(t, err)
+	assert.Equal(t, `{"kind":"Deployment","name":"web",`+
+		`"left":{"context":"staging","namespace":"apps"},"right":{"context":"production","namespace":"apps"},"identical":false,"differences":[`+
+		`{"path":".metadata.annotations.deployment\\.kubernetes\\.io/revision","change":"changed","left":"1","right":"2"},`+
+		`{"path":".metadata.labels.app\\.kubernetes\\.io/version","change":"added","right":"1.0"},`+
+		`{"path":".metadata.labels.canary","change":"removed","left":"true"},`+
+		`{"path":".spec.replicas","change":"changed","left":1,"right":3},`+
+		`{"path":".spec.template.spec.containers[0].image","change":"changed","left":"web:1.1","right":"web:1.0"}]}`,
+		result.Content[0].(mcp.TextContent).Text)
+
+	result, err = tool.Handler(context.Background(), mcp.CallToolRequest{Params: mcp.CallToolParams{Arguments: map[string]any{
+		"kind":      "Deployment",
+		"name":      "web",
+		"namespace": "apps",
+		"contexts":  []any{"staging", "admin"},
+	}}})
+	assert.Error(t, err)
+	assert.Nil(t, result)
+
+	result, err = tool.Handler(context.Background(), mcp.CallToolRequest{Params: mcp.CallToolParams{Arguments: map[string]any{
+		"kind":      "Deployment",
+		"name":      "web",
+		"namespace": "apps",
+		"contexts":  []any{"staging", "production"},
+		"output":    "table",
+	}}})
+	assert.NoError(t, err)
+	assert.Equal(t, `# Deployment web: staging/apps vs production/apps
+PATH                                                        CHANGE    LEFT      RIGHT
+.metadata.annotations.deployment\.kubernetes\.io/revision   changed   1         2
+.metadata.labels.app\.kubernetes\.io/version                added     <none>    1.0
+.metadata.labels.canary                                     removed   true      <none>
+.spec.replicas                                              changed   1         3
+.spec.template.spec.containers[0].image                     changed   web:1.1   web:1.0
+
+5 difference(s)
+`, result.Content[0].(mcp.TextContent).Text)
+}
+
+func TestCompareTool_Namespaces(t *testing.T) {
+	client := FakeObjectsClient{resources: workloadTestResources, objects: []runtime.Object{
+		newCompareTestDeployment("team-a", "1", 2, "web:1.0", map[string]string{"app": "web"}),
+		newCompareTestDeployment("team-b", "1", 2, "web:1.0", map[string]string{"app": "web"}),
+	}}
+	tool := NewCompareTool(NewFakeMultiClusterClient(client), DefaultOptions())
+
+	result, err := tool.Handler(context.Background(), mcp.CallToolRequest{Params: mcp.CallToolParams{Arguments: map[string]any{
+		"kind":       "deploy",
+		"name":       "web",
+		"namespaces": []any{"team-a", "team-b"},
+		"output":     "table",
+	}}})
+	assert.NoError(t, err)
+	assert.Equal(t, "# Deployment web: test-context/team-a vs test-context/team-b\nno differences\n", result.Content[0].(mcp.TextContent).Text)
+}
+
+func TestCompareTool_RedactsDifferences(t *testing.T) {
+	secret := func(namespace, password string) *unstructured.Unstructured {
+		obj := newUnstructured("v1", "Secret", namespace, "db")
+		obj.Object["data"] = map[string]any{"username": "YWRtaW4=", "password": password}
+		return obj
+	}
+	client := FakeObjectsClient{resources: workloadTestResources, objects: []runtime.Object{
+		secret("team-a", "c2VjcmV0LWE="),
+		secret("team-b", "c2VjcmV0LWI="),
+	}}
+	opts := DefaultOptions()
+	resources, err := policy.NewResourcePolicy(nil, nil)
+	assert.NoError(t, err)
+	opts.Resources = resources
+	tool := NewCompareTool(NewFakeMultiClusterClient(client), opts)
+
+	result, err := tool.Handler(context.Background(), mcp.CallToolRequest{Params: mcp.CallToolParams{Arguments: map[string]any{
+		"kind":       "Secret",
+		"name":       "db",
+		"namespaces": []any{"team-a", "team-b"},
+	}}})
+	assert.NoError(t, err)
+	text := result.Content[0].(mcp.TextContent).Text
+	assert.Equal(t, `{"kind":"Secret","name":"db",`+
+		`"left":{"context":"test-context","namespace":"team-a"},"right":{"context":"test-context","namespace":"team-b"},"identical":false,"differences":[`+
+		`{"path":".data.password","change":"changed","left":"[REDACTED]","right":"[REDACTED]"}]}`, text)
+	assert.NotContains(t, text, "c2VjcmV0")
+	assert.Equal(t, 2, result.Meta[redactionsMetaKey])
+}
+
+func TestDiffValues(t *testing.T) {
+	testCases := []struct {
+		name     string
+		left     any
+		right    any
+		expected []Difference
+	}{
+		{
+			name:  "equal",
+			left:  map[string]any{"a": []any{int64(1), "x"}},
+			right: map[string]any{"a": []any{int64(1), "x"}},
+		},
+		{
+			name:  "list lengths",
+			left:  map[string]any{"ports": []any{int64(80)}},
+			right: map[string]any{"ports": []any{int64(80), int64(443)}},
+			expected: []Difference{
+				{Path: ".ports[1]", Change: changeAdded, Right: int64(443)},
+			},
+		},
+		{
+			name:  "type change",
+			left:  map[string]any{"a": map[string]any{"b": "c"}},
+			right: map[string]any{"a": "c"},
+			expected: []Difference{
+				{Path: ".a", Change: changeChanged, Left: map[string]any{"b": "c"}, Right: "c"},
+			},
+		},
+	}
+
+	for _, tc := range testCases {
+		t.Run(tc.name, func(t *testing.T) {
+			var diffs []Difference
+			diffValues("", tc.left, tc.right, &diffs)
+			assert.Equal(t, tc.expected, diffs)
+		})
+	}
+}
+
+func TestParseAndValidateCompareParams(t *testing.T) {
+	input, err := parseAndValidateCompareParams(map[string]any{"kind": "Deployment", "name": "web", "namespace": "apps", "contexts": "staging,production"})
+	assert.NoError(t, err)
+	assert.Equal(t, []string{"staging", "production"}, input.Contexts)
+
+	for _, args := range []map[string]any{
+		{"kind": "Deployment", "name": "web"},
+		{"kind": "Deployment", "name": "web", "contexts": []any{"staging"}},
+		{"kind": "Deployment", "name": "web", "contexts": []any{"staging", "staging"}},
+		{"kind": "Deployment", "name": "web", "contexts": []any{"staging", "*"}},
+		{"kind": "Deployment", "name": "web", "contexts": []any{"a", "b"}, "namespaces": []any{"a", "b"}},
+		{"kind": "Deployment", "name": "web", "contexts": []any{"a", "b"}, "context": "a"},
+		{"kind": "Deployment", "name": "web", "namespaces": []any{"a", "b"}, "namespace": "a"},
+		{"kind": "Deployment", "name": "web", "namespaces": []any{"a", "b"}, "output": "name"},
+		{"name": "web", "namespaces": []any{"a", "b"}},
+	} {
+		_, err := parseAndValidateCompareParams(args)
+		assert.Error(t, err, args)
+	}
+}
@@ -0,0 +1,199 @@
+package tools
+
+import (
+	"bytes"
+	"context"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"slices"
+	"strings"
+	"time"
+
+	"github.com/mark3labs/mcp-go/mcp"
+	"github.com/mark3labs/mcp-go/server"
+	"sigs.k8s.io/yaml"
+)
+
+// allContexts in the "contexts" argument selects every context allowed by the context policy.
+const allContexts = "*"
+
+// maxConcurrentContexts is the number of contexts a multi-context request runs in at the same time.
+const maxConcurrentContexts = 8
+
+// withContexts adds the "contexts" argument, which runs a request against several contexts at once.
+func withContexts() mcp.ToolOption {
+	return mcp.WithArray("contexts",
+		mcp.Description("Run the request against several Kubernetes contexts in parallel, e.g. ['production', 'staging'], or ['*'] for every allowed context. The results are keyed by context, each with its error and latency. Cannot be combined with context"),
+		mcp.Items(map[string]any{"type": "string"}),
+	)
+}
+
+// parseContexts reads the "contexts" argument, given as a list or a comma-separated string.
+func parseContexts(args map[string]any) ([]string, error) {
+	contexts, err := parseStringList(args, "contexts")
+	if err != nil || len(contexts) == 0 {
+		return nil, err
+	}
+	if context, _ := args["context"].(string); context != "" {
+		return nil, errors.New("context cannot be combined with contexts")
+	}
+	if output, _ := args["output"].(string); output == outputName {
+		return nil, fmt.Errorf("contexts cannot be combined with output '%s'", output)
+	}
+	return contexts, nil
+}
+
+// parseStringList reads a list of strings given either as a list or a comma-separated string.
+// Blank and repeated values are dropped.
+func parseStringList(args map[string]any, key string) ([]string, error) {
+	var values []string
+	switch v := args[key].(type) {
+	case nil:
+		return nil, nil
+	case string:
+		values = strings.Split(v, ",")
+	case []any:
+		for _, item := range v {
+			s, ok := item.(string)
+			if !ok {
+				return nil, fmt.Errorf("%s must be a list of strings, got %T", key, item)
+			}
+			values = append(values, s)
+		}
+	case []string:
+		values = v
+	default:
+		return nil, fmt.Errorf("%s must be a list of strings, got %T", key, v)
+	}
+
+	var list []string
+	for _, value := range values {
+		if value = strings.TrimSpace(value); value != "" && !slices.Contains(list, value) {
+			list = append(list, value)
+		}
+	}
+	return list, nil
+}
+
+// ContextsResult is the result of a request run against several contexts, keyed by context.
+type ContextsResult struct {
+	Contexts map[string]ContextResult `json:"contexts"`
+}
+
+// ContextResult is the result of a request in one context, or the error it failed with. One
+// context failing, for example because it is unreachable, does not fail the others.
+type ContextResult struct {
+	Result    json.RawMessage `json:"result,omitempty"`
+	Error     string          `json:"error,omitempty"`
+	LatencyMs int64           `json:"latencyMs"`
+
+	// text is the result as printed with output=table
+	text string
+}
+
+// resolveContexts expands "*" into every context allowed by the context policy.
+func resolveContexts(multiClient MultiClusterClientInterface, contexts []string) ([]string, error) {
+	if !slices.Contains(contexts, allContexts) {
+		return contexts, nil
+	}
+	infos, err := multiClient.ListContexts()
+	if err != nil {
+		return nil, fmt.Errorf("failed to list contexts: %w", err)
+	}
+	resolved := make([]string, 0, len(infos))
+	for _, info := range infos {
+		resolved = append(resolved, info.Name)
+	}
+	return resolved, nil
+}
+
+// forEachContext runs handler once per context, in up to maxConcurrentContexts contexts at a time,
+// with the request's "contexts" argument replaced by "context", and combines the results keyed by
+// context. The handler's results are already redacted, so they are only counted here.
+func (o Options) forEachContext(ctx context.Context, multiClient MultiClusterClientInterface, req mcp.CallToolRequest, contexts []string, handler server.ToolHandlerFunc) (*mcp.CallToolResult, error) {
+	contexts, err := resolveContexts(multiClient, contexts)
+	if err != nil {
+		return nil, err
+	}
+
+	args := req.GetArguments()
+	output, _ := args["output"].(string)
+	results := make([]ContextResult, len(contexts))
+	redactions := make([]int, len(contexts))
+	runWorkers(len(contexts), maxConcurrentContexts, func(i int) {
+		contextReq := req
+		contextReq.Params.Arguments = contextArguments(args, contexts[i])
+		start := time.Now()
+		result, err := handler(ctx, contextReq)
+		results[i], redactions[i] = contextResult(result, err, output)
+		results[i].LatencyMs = time.Since(start).Milliseconds()
+	})
+
+	combined := ContextsResult{Contexts: make(map[string]ContextResult, len(contexts))}
+	total := 0
+	for i, contextName := range contexts {
+		combined.Contexts[contextName] = results[i]
+		total += redactions[i]
+	}
+
+	if output == outputTable {
+		var out []string
+		for i, contextName := range contexts {
+			out = append(out, fmt.Sprintf("# context %s (%dms)\n%s", contextName, results[i].LatencyMs, results[i].text))
+		}
+		return newToolResult([]byte(strings.Join(out, "\n")), total), nil
+	}
+
+	var buf bytes.Buffer
+	encoder := json.NewEncoder(&buf)
+	encoder.SetEscapeHTML(false)
+	if err := encoder.Encode(combined); err != nil {
+		return nil, fmt.Errorf("failed to marshal output: %w", err)
+	}
+	out := bytes.TrimSuffix(buf.Bytes(), []byte("\n"))
+	if output == outputYAML {
+		var err error
+		if out, err = yaml.JSONToYAML(out); err != nil {
+			return nil, fmt.Errorf("failed to convert output to YAML: %w", err)
+		}
+	}
+	return newToolResult(out, total), nil
+}
+
+// contextArguments returns a copy of args for the request in one context. YAML output is produced
+// from the combined JSON, so each context returns JSON.
+func contextArguments(args map[string]any, contextName string) map[string]any {
+	contextArgs := make(map[string]any, len(args))
+	for key, value := range args {
+		contextArgs[key] = value
+	}
+	delete(contextArgs, "contexts")
+	contextArgs["context"] = contextName
+	if contextArgs["output"] == outputYAML {
+		delete(contextArgs, "output")
+	}
+	return contextArgs
+}
+
+// contextResult converts the result of the request in one context, returning it with the number
+// of values redacted from it.
+func contextResult(result *mcp.CallToolResult, err error, output string) (ContextResult, int) {
+	if err == nil && (result == nil || len(result.Content) == 0) {
+		err = errors.New("empty result")
+	}
+	if err != nil {
+		return ContextResult{Error: err.Error(), text: "error: " + err.Error() + "\n"}, 0
+	}
+
+	text, _ := result.Content[0].(mcp.TextContent)
+	redactions, _ := result.Meta[redactionsMetaKey].(int)
+	if output == outputTable {
+		return ContextResult{text: text.Text}, redactions
+	}
+	raw := json.RawMessage(text.Text)
+	if !json.Valid(raw) {
+		raw, _ = json.Marshal(text.Text)
+	}
+	return ContextResult{Result: raw}, redactions
+}
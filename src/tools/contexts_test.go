@@ -0,0 +1,194 @@
+package tools
+
+import (
+	"context"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"sync/atomic"
+	"testing"
+	"time"
+
+	"github.com/mark3labs/mcp-go/mcp"
+	"github.com/stretchr/testify/assert"
+	"k8s.io/apimachinery/pkg/runtime"
+)
+
+// ContextsMultiClusterClient serves a client per context; other contexts are blocked
+type ContextsMultiClusterClient struct {
+	clients map[string]Client
+}
+
+func (c *ContextsMultiClusterClient) GetClient(ctx context.Context, contextName string) (Client, error) {
+	if contextName == "" {
+		contextName = c.GetDefaultContext()
+	}
+	client, ok := c.clients[contextName]
+	if !ok {
+		return nil, fmt.Errorf("context '%s' is blocked by the server's context policy", contextName)
+	}
+	return client, nil
+}
+
+func (c *ContextsMultiClusterClient) GetDefaultContext() string {
+	return "staging"
+}
+
+func (c *ContextsMultiClusterClient) ListContexts() ([]ContextInfo, error) {
+	return []ContextInfo{{Name: "production"}, {Name: "staging"}}, nil
+}
+
+// newContextsTestClient returns a client for the production and staging contexts, each running a
+// different version of the web deployment
+func newContextsTestClient() *ContextsMultiClusterClient {
+	deployment := func(image string) FakeObjectsClient {
+		obj := newUnstructured("apps/v1", "Deployment", "apps", "web")
+		obj.Object["spec"] = map[string]any{"template": map[string]any{"spec": map[string]any{
+			"containers": []any{map[string]any{"name": "web", "image": image}},
+		}}}
+		return FakeObjectsClient{resources: workloadTestResources, objects: []runtime.Object{obj}}
+	}
+	return &ContextsMultiClusterClient{clients: map[string]Client{
+		"production": deployment("web:1.0"),
+		"staging":    deployment("web:1.1"),
+	}}
+}
+
+// contextsResult decodes the result of a request run against several contexts
+func contextsResult(t *testing.T, result *mcp.CallToolResult) map[string]map[string]any {
+	t.Helper()
+	var decoded struct {
+		Contexts map[string]map[string]any `json:"contexts"`
+	}
+	assert.NoError(t, json.Unmarshal([]byte(result.Content[0].(mcp.TextContent).Text), &decoded))
+	return decoded.Contexts
+}
+
+func TestListTool_Contexts(t *testing.T) {
+	l := NewListTool(newContextsTestClient(), DefaultOptions())
+
+	result, err := l.Handler(context.Background(), mcp.CallToolRequest{Params: mcp.CallToolParams{Arguments: map[string]any{
+		"kind":      "Deployment",
+		"namespace": "apps",
+		"fields":    []any{".spec.template.spec.containers[0].image"},
+		"contexts":  []any{"production", "staging", "admin"},
+	}}})
+	assert.NoError(t, err)
+	contexts := contextsResult(t, result)
+	assert.Len(t, contexts, 3)
+	assert.Equal(t, map[string]any{"items": []any{map[string]any{
+		".spec.template.spec.containers[0].image": "web:1.0", "name": "web", "namespace": "apps",
+	}}}, contexts["production"]["result"])
+	assert.Equal(t, "web:1.1", contexts["staging"]["result"].(map[string]any)["items"].([]any)[0].(map[string]any)[".spec.template.spec.containers[0].image"])
+	assert.Contains(t, contexts["staging"], "latencyMs")
+	assert.Equal(t, "failed to get client for context 'admin': context 'admin' is blocked by the server's context policy", contexts["admin"]["error"])
+	assert.NotContains(t, contexts["admin"], "result")
+
+	result, err = l.Handler(context.Background(), mcp.CallToolRequest{Params: mcp.CallToolParams{Arguments: map[string]any{
+		"kind":     "Deployment",
+		"contexts": []any{"*"},
+		"output":   "yaml",
+	}}})
+	assert.NoError(t, err)
+	text := result.Content[0].(mcp.TextContent).Text
+	assert.Contains(t, text, "contexts:\n  production:\n")
+	assert.Contains(t, text, "\n  staging:\n")
+
+	for _, args := range []map[string]any{
+		{"kind": "Deployment", "context": "staging", "contexts": []any{"production"}},
+		{"kind": "Deployment", "contexts": []any{"production"}, "output": "name"},
+		{"kind": "Deployment", "contexts": []any{1}},
+	} {
+		_, err := l.Handler(context.Background(), mcp.CallToolRequest{Params: mcp.CallToolParams{Arguments: args}})
+		assert.Error(t, err, args)
+	}
+}
+
+func TestDescribeTool_Contexts(t *testing.T) {
+	d := NewDescribeTool(newContextsTestClient(), DefaultOptions())
+
+	result, err := d.Handler(context.Background(), mcp.CallToolRequest{Params: mcp.CallToolParams{Arguments: map[string]any{
+		"kind":      "Deployment",
+		"name":      "web",
+		"namespace": "apps",
+		"fields":    []any{".spec.template.spec.containers[0].image"},
+		"contexts":  "production, staging",
+	}}})
+	assert.NoError(t, err)
+	contexts := contextsResult(t, result)
+	assert.Equal(t, "web:1.0", contexts["production"]["result"].(map[string]any)[".spec.template.spec.containers[0].image"])
+	assert.Equal(t, "web:1.1", contexts["staging"]["result"].(map[string]any)[".spec.template.spec.containers[0].image"])
+
+	result, err = d.Handler(context.Background(), mcp.CallToolRequest{Params: mcp.CallToolParams{Arguments: map[string]any{
+		"kind":      "Deployment",
+		"name":      "api",
+		"namespace": "apps",
+		"contexts":  []any{"production"},
+		"output":    "table",
+	}}})
+	assert.NoError(t, err)
+	text := result.Content[0].(mcp.TextContent).Text
+	assert.Regexp(t, `^# context production \(\d+ms\)\nerror: failed to get resource Deployment/api: `, text)
+}
+
+func TestListEventsTool_Contexts(t *testing.T) {
+	multiClient := &ContextsMultiClusterClient{clients: map[string]Client{
+		"production": &FakeEventsClient{err: errors.New("clientset error")},
+	}}
+	tool := NewListEventsTool(multiClient, DefaultOptions())
+
+	result, err := tool.Handler(context.Background(), mcp.CallToolRequest{Params: mcp.CallToolParams{Arguments: map[string]any{
+		"namespace": "default",
+		"contexts":  []any{"*"},
+	}}})
+	assert.NoError(t, err)
+	contexts := contextsResult(t, result)
+	assert.Equal(t, "failed to get clientset: clientset error", contexts["production"]["error"])
+	assert.Equal(t, "failed to get client for context 'staging': context 'staging' is blocked by the server's context policy", contexts["staging"]["error"])
+}
+
+func TestParseContexts(t *testing.T) {
+	testCases := []struct {
+		name     string
+		args     map[string]any
+		expected []string
+	}{
+		{name: "absent", args: map[string]any{}},
+		{name: "list", args: map[string]any{"contexts": []any{"prod", " staging ", "prod"}}, expected: []string{"prod", "staging"}},
+		{name: "comma-separated", args: map[string]any{"contexts": "prod,staging"}, expected: []string{"prod", "staging"}},
+		{name: "all", args: map[string]any{"contexts": []any{"*"}}, expected: []string{"*"}},
+		{name: "empty", args: map[string]any{"contexts": []any{}, "context": "prod"}},
+	}
+
+	for _, tc := range testCases {
+		t.Run(tc.name, func(t *testing.T) {
+			contexts, err := parseContexts(tc.args)
+			assert.NoError(t, err)
+			assert.Equal(t, tc.expected, contexts)
+		})
+	}
+}
+
+func TestForEachContext_Bounded(t *testing.T) {
+	var contexts []string
+	for i := range 3 * maxConcurrentContexts {
+		contexts = append(contexts, fmt.Sprintf("cluster-%d", i))
+	}
+	var running, peak atomic.Int32
+	handler := func(ctx context.Context, req mcp.CallToolRequest) (*mcp.CallToolResult, error) {
+		n := running.Add(1)
+		defer running.Add(-1)
+		for p := peak.Load(); n > p && !peak.CompareAndSwap(p, n); p = peak.Load() {
+		}
+		time.Sleep(10 * time.Millisecond)
+		return mcp.NewToolResultText(fmt.Sprintf(`{"context":%q}`, req.GetArguments()["context"])), nil
+	}
+
+	result, err := DefaultOptions().forEachContext(context.Background(), newContextsTestClient(), mcp.CallToolRequest{}, contexts, handler)
+	assert.NoError(t, err)
+	results := contextsResult(t, result)
+	assert.Len(t, results, len(contexts))
+	assert.Equal(t, map[string]any{"context": "cluster-5"}, results["cluster-5"]["result"])
+	assert.LessOrEqual(t, peak.Load(), int32(maxConcurrentContexts))
+	assert.Greater(t, peak.Load(), int32(1))
+}
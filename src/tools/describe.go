@@ -2,7 +2,6 @@ package tools
 
 import (
 	"context"
-	"encoding/json"
 	"errors"
 	"fmt"
 
@@ -12,18 +11,23 @@ import (
 )
 
 type DescribeResourceInput struct {
-	Context   string `json:"context,omitempty"`
-	Kind      string `json:"kind"`
-	Name      string `json:"name"`
-	Namespace string `json:"namespace,omitempty"`
+	Context   string   `json:"context,omitempty"`
+	Contexts  []string `json:"contexts,omitempty"`
+	Kind      string   `json:"kind"`
+	Name      string   `json:"name"`
+	Namespace string   `json:"namespace,omitempty"`
+	Fields    []string `json:"fields,omitempty"`
+	Output    string   `json:"output,omitempty"`
+	Raw       bool     `json:"raw,omitempty"`
 }
 
 type DescribeTool struct {
 	multiClient MultiClusterClientInterface
+	opts        Options
 }
 
-func NewDescribeTool(multiClient MultiClusterClientInterface) *DescribeTool {
-	return &DescribeTool{multiClient: multiClient}
+func NewDescribeTool(multiClient MultiClusterClientInterface, opts Options) *DescribeTool {
+	return &DescribeTool{multiClient: multiClient, opts: opts}
 }
 
 func (d *DescribeTool) Tool() mcp.Tool {
@@ -32,9 +36,10 @@ func (d *DescribeTool) Tool() mcp.Tool {
 		mcp.WithString("context",
 			mcp.Description("Kubernetes context name from kubeconfig to use for this request (leave empty for current context)"),
 		),
+		withContexts(),
 		mcp.WithString("kind",
 			mcp.Required(),
-			mcp.Description("Kind of the Kubernetes resource, e.g., Pod, Deployment, Service, ConfigMap, or any CRD"),
+			mcp.Description("Kind of the Kubernetes resource, e.g., Pod, Deployment, Service, ConfigMap, or any CRD. Qualify it with its API group as kind.group (e.g., 'certificates.cert-manager.io') or group/version/kind (e.g., 'apps/v1/Deployment') when it exists in several groups"),
 		),
 		mcp.WithString("name",
 			mcp.Required(),
@@ -43,22 +48,31 @@ func (d *DescribeTool) Tool() mcp.Tool {
 		mcp.WithString("namespace",
 			mcp.Description("Kubernetes namespace of the resource (leave empty to search all namespaces, use 'default' for default namespace)"),
 		),
+		mcp.WithArray("fields",
+			mcp.Description("JSONPath expressions to return instead of the whole description, e.g. ['.spec.replicas', '.status.conditions[?(@.type==\"Ready\")].status']"),
+			mcp.Items(map[string]any{"type": "string"}),
+		),
+		withRaw(),
+		withOutput(),
 	)
 }
 
 func (d *DescribeTool) Handler(ctx context.Context, req mcp.CallToolRequest) (*mcp.CallToolResult, error) {
-	input, err := parseAndValidateDescribeParams(req.Params.Arguments)
+	input, err := parseAndValidateDescribeParams(req.GetArguments())
 	if err != nil {
 		return nil, err
 	}
+	if len(input.Contexts) > 0 {
+		return d.opts.forEachContext(ctx, d.multiClient, req, input.Contexts, d.Handler)
+	}
 
 	// Get the appropriate client for the context
-	client, err := d.multiClient.GetClient(input.Context)
+	client, err := d.multiClient.GetClient(ctx, input.Context)
 	if err != nil {
 		return nil, fmt.Errorf("failed to get client for context '%s': %w", input.Context, err)
 	}
 
-	gvrMatch, err := d.discoverResourceByKind(client, input.Kind)
+	gvrMatch, err := resolveKind(client, input.Kind)
 	if err != nil {
 		return nil, err
 	}
@@ -68,32 +82,73 @@ func (d *DescribeTool) Handler(ctx context.Context, req mcp.CallToolRequest) (*m
 		return nil, err
 	}
 
-	describeOutput := d.formatResourceDescription(resource)
+	gvr := gvrMatch.ToGroupVersionResource()
+	name := objectName(resource.GetKind(), *gvr, resource.GetName())
 
-	out, err := json.Marshal(describeOutput)
-	if err != nil {
-		return nil, fmt.Errorf("failed to marshal describe output: %w", err)
+	if input.Output == outputTable {
+		table, err := serverTable(ctx, client, serverTableRequest{
+			gvr:          *gvr,
+			namespaced:   gvrMatch.namespaced,
+			namespace:    resource.GetNamespace(),
+			name:         resource.GetName(),
+			access:       d.opts.namespaceAccess(d.multiClient, input.Context),
+			metadataOnly: d.opts.Resources.MetadataOnly(*gvr),
+		})
+		if err != nil {
+			return nil, err
+		}
+		return d.opts.formatOutput(table, input.Output, 0)
+	}
+
+	if len(input.Fields) > 0 {
+		return d.describeFields(resource, input)
+	}
+
+	if !input.Raw {
+		d.opts.Pruner.Object(resource.Object)
+	}
+	describeOutput := d.formatResourceDescription(resource)
+	if d.opts.Resources.MetadataOnly(*gvr) {
+		// Only the identity, type and key names of sensitive resources are returned
+		for _, field := range []string{"type", "keys"} {
+			if value, found := resource.Object[field]; found {
+				describeOutput[field] = value
+			}
+		}
+		describeOutput["metadataOnly"] = true
 	}
 
-	return mcp.NewToolResultText(string(out)), nil
+	return d.opts.formatOutput(namedValue{value: describeOutput, objectNames: []string{name}}, input.Output, 0)
 }
 
-func (d *DescribeTool) discoverResourceByKind(client Client, kind string) (*gvrMatch, error) {
-	discoClient, err := client.DiscoClient()
+// describeFields returns the name and namespace of resource with the value of each requested field.
+func (d *DescribeTool) describeFields(resource *unstructured.Unstructured, input *DescribeResourceInput) (*mcp.CallToolResult, error) {
+	projection, err := newFieldProjection(input.Fields)
 	if err != nil {
-		return nil, fmt.Errorf("failed to create discovery client: %w", err)
+		return nil, err
 	}
-
-	apiResourceLists, err := discoClient.ServerPreferredResources()
+	projected, redactions, err := d.opts.projectFields([]map[string]any{resource.Object}, projection)
 	if err != nil {
-		return nil, fmt.Errorf("failed to discover resources: %w", err)
+		return nil, err
 	}
 
-	return findGVRByKind(apiResourceLists, kind)
+	return d.opts.formatOutput(projected[0], input.Output, redactions)
 }
 
 func (d *DescribeTool) getResource(ctx context.Context, client Client, gvrMatch *gvrMatch, input *DescribeResourceInput) (*unstructured.Unstructured, error) {
-	ri, err := client.ResourceInterface(*gvrMatch.ToGroupVersionResource(), gvrMatch.namespaced, input.Namespace)
+	gvr := gvrMatch.ToGroupVersionResource()
+	if err := d.opts.checkResource(*gvr); err != nil {
+		return nil, err
+	}
+
+	access := d.opts.namespaceAccess(d.multiClient, input.Context)
+	if gvrMatch.namespaced {
+		if err := access.check(input.Namespace); err != nil {
+			return nil, err
+		}
+	}
+
+	ri, err := client.ResourceInterface(*gvr, gvrMatch.namespaced, input.Namespace)
 	if err != nil {
 		return nil, fmt.Errorf("failed to create resource interface: %w", err)
 	}
@@ -103,6 +158,19 @@ func (d *DescribeTool) getResource(ctx context.Context, client Client, gvrMatch
 		return nil, fmt.Errorf("failed to get resource %s/%s: %w", input.Kind, input.Name, err)
 	}
 
+	// Without a namespace in the request, the resource may come from any namespace
+	namespace := resource.GetNamespace()
+	if gvr.Group == "" && gvr.Resource == "namespaces" {
+		namespace = resource.GetName()
+	}
+	if err := access.check(namespace); err != nil {
+		return nil, err
+	}
+
+	if d.opts.Resources.MetadataOnly(*gvr) {
+		resource = metadataOnlyObject(resource)
+	}
+
 	return resource, nil
 }
 
@@ -140,10 +208,15 @@ func (d *DescribeTool) formatResourceDescription(resource *unstructured.Unstruct
 func parseAndValidateDescribeParams(args map[string]any) (*DescribeResourceInput, error) {
 	input := &DescribeResourceInput{}
 
-	// Optional: context
+	// Optional: context, or contexts to describe the resource in each of them
 	if context, ok := args["context"].(string); ok {
 		input.Context = context
 	}
+	contexts, err := parseContexts(args)
+	if err != nil {
+		return nil, err
+	}
+	input.Contexts = contexts
 
 	if kindVal, ok := args["kind"].(string); ok && kindVal != "" {
 		input.Kind = kindVal
@@ -164,5 +237,24 @@ func parseAndValidateDescribeParams(args map[string]any) (*DescribeResourceInput
 		input.Namespace = metav1.NamespaceAll
 	}
 
+	output, err := parseOutput(args)
+	if err != nil {
+		return nil, err
+	}
+	input.Output = output
+
+	if raw, ok := args["raw"].(bool); ok {
+		input.Raw = raw
+	}
+
+	fields, err := parseFields(args)
+	if err != nil {
+		return nil, err
+	}
+	if len(fields) > 0 && (output == outputTable || output == outputName) {
+		return nil, fmt.Errorf("fields cannot be combined with output '%s'", output)
+	}
+	input.Fields = fields
+
 	return input, nil
 }
\ No newline at end of file
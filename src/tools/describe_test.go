@@ -4,6 +4,7 @@ import (
 	"context"
 	"testing"
 
+	"github.com/kkb0318/kubernetes-mcp/src/policy"
 	"github.com/mark3labs/mcp-go/mcp"
 	"github.com/stretchr/testify/assert"
 	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
@@ -13,6 +14,7 @@ import (
 	"k8s.io/apimachinery/pkg/api/meta"
 	"k8s.io/client-go/discovery"
 	"k8s.io/client-go/dynamic"
+	"k8s.io/client-go/rest"
 	"k8s.io/client-go/kubernetes"
 	"k8s.io/apimachinery/pkg/watch"
 )
@@ -101,6 +103,10 @@ func (f FakeDescribeKubernetesClient) RESTMapper() (meta.RESTMapper, error) {
 	return nil, nil
 }
 
+func (f FakeDescribeKubernetesClient) RESTClient() (rest.Interface, error) {
+	return nil, nil
+}
+
 func (f FakeDescribeKubernetesClient) ResourceInterface(gvr schema.GroupVersionResource, namespaced bool, ns string) (dynamic.ResourceInterface, error) {
 	return &FakeDescribeResourceInterface{resource: f.resource}, nil
 }
@@ -108,7 +114,7 @@ func (f FakeDescribeKubernetesClient) ResourceInterface(gvr schema.GroupVersionR
 func TestDescribeTool_Tool(t *testing.T) {
 	client := FakeDescribeKubernetesClient{}
 	multiClient := NewFakeMultiClusterClient(client)
-	tool := NewDescribeTool(multiClient)
+	tool := NewDescribeTool(multiClient, DefaultOptions())
 
 	mcpTool := tool.Tool()
 
@@ -213,7 +219,7 @@ func TestDescribeTool_Handler(t *testing.T) {
 	for _, tt := range testCases {
 		t.Run(tt.name, func(t *testing.T) {
 			multiClient := NewFakeMultiClusterClient(tt.client)
-			tool := NewDescribeTool(multiClient)
+			tool := NewDescribeTool(multiClient, DefaultOptions())
 			req := &mcp.CallToolRequest{}
 			req.Params.Arguments = tt.request
 
@@ -340,7 +346,7 @@ func TestParseAndValidateDescribeParams(t *testing.T) {
 func TestDescribeTool_FormatResourceDescription(t *testing.T) {
 	client := FakeDescribeKubernetesClient{}
 	multiClient := NewFakeMultiClusterClient(client)
-	tool := NewDescribeTool(multiClient)
+	tool := NewDescribeTool(multiClient, DefaultOptions())
 
 	testPod := &unstructured.Unstructured{}
 	testPod.SetName("test-pod")
@@ -418,7 +424,7 @@ func TestDescribeTool_FormatResourceDescription(t *testing.T) {
 func TestDescribeTool_FormatResourceDescriptionWithoutSpecStatus(t *testing.T) {
 	client := FakeDescribeKubernetesClient{}
 	multiClient := NewFakeMultiClusterClient(client)
-	tool := NewDescribeTool(multiClient)
+	tool := NewDescribeTool(multiClient, DefaultOptions())
 
 	// Create a resource without spec and status
 	testConfigMap := &unstructured.Unstructured{}
@@ -454,3 +460,31 @@ func TestDescribeTool_FormatResourceDescriptionWithoutSpecStatus(t *testing.T) {
 	assert.False(t, statusExists)
 }
 
+
+func TestDescribeTool_NamespacePolicy(t *testing.T) {
+	namespaces, err := policy.NewNamespacePolicy([]policy.NamespaceRule{{Denied: []string{"kube-system"}}})
+	assert.NoError(t, err)
+	opts := DefaultOptions()
+	opts.Namespaces = namespaces
+
+	systemPod := newUnstructured("v1", "Pod", "kube-system", "coredns")
+	tool := NewDescribeTool(NewFakeMultiClusterClient(FakeDescribeKubernetesClient{resource: systemPod}), opts)
+
+	testCases := []struct {
+		name    string
+		request map[string]any
+	}{
+		{name: "denied namespace in request", request: map[string]any{"kind": "Pod", "name": "coredns", "namespace": "kube-system"}},
+		{name: "resource found in denied namespace", request: map[string]any{"kind": "Pod", "name": "coredns"}},
+	}
+
+	for _, tc := range testCases {
+		t.Run(tc.name, func(t *testing.T) {
+			req := mcp.CallToolRequest{Params: mcp.CallToolParams{Arguments: tc.request}}
+			result, err := tool.Handler(context.Background(), req)
+			assert.Error(t, err)
+			assert.Contains(t, err.Error(), "namespace 'kube-system' is blocked")
+			assert.Nil(t, result)
+		})
+	}
+}
@@ -0,0 +1,113 @@
+package tools
+
+import (
+	"errors"
+	"fmt"
+
+	apierrors "k8s.io/apimachinery/pkg/api/errors"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/runtime/schema"
+	"k8s.io/client-go/discovery/cached/memory"
+)
+
+// serverPreferredResources returns the preferred version of every API resource from the client's
+// discovery cache.
+func serverPreferredResources(client Client) ([]*metav1.APIResourceList, error) {
+	discoClient, err := client.DiscoClient()
+	if err != nil {
+		return nil, fmt.Errorf("failed to create discovery client: %w", err)
+	}
+
+	apiResourceLists, err := discoClient.ServerPreferredResources()
+	if err != nil {
+		return nil, fmt.Errorf("failed to discover resources: %w", err)
+	}
+	return apiResourceLists, nil
+}
+
+// resolveKind finds the API resource for a kind, plural name or short name using the client's
+// discovery cache. If the kind is not found, the cache may be stale (for example, a CRD was just
+// installed), so it is invalidated and the lookup retried once against fresh discovery data.
+// See findGVRByKind for the accepted forms of kind.
+func resolveKind(client Client, kind string) (*gvrMatch, error) {
+	matches, err := resolveKinds(client, []string{kind})
+	if err != nil {
+		return nil, err
+	}
+	return matches[0], nil
+}
+
+// resolveKinds resolves several kinds like resolveKind, against a single snapshot of the
+// discovery data.
+func resolveKinds(client Client, kinds []string) ([]*gvrMatch, error) {
+	matches, err := lookupKinds(client, kinds)
+	var notFound *kindNotFoundError
+	if !errors.As(err, &notFound) {
+		return matches, err
+	}
+
+	invalidator, ok := client.(DiscoveryInvalidator)
+	if !ok {
+		return nil, err
+	}
+	invalidator.InvalidateDiscovery()
+
+	return lookupKinds(client, kinds)
+}
+
+// kindNotFoundError is returned when no API resource matches a kind. Only this error refreshes
+// discovery; failures such as unreachable or forbidden discovery endpoints are returned as is.
+type kindNotFoundError struct {
+	kind string
+}
+
+func (e *kindNotFoundError) Error() string {
+	return fmt.Sprintf("cannot find resource '%s'", e.kind)
+}
+
+// lookupKinds resolves each kind with lookupKind, fetching the preferred resources only once.
+func lookupKinds(client Client, kinds []string) ([]*gvrMatch, error) {
+	var preferred []*metav1.APIResourceList
+	matches := make([]*gvrMatch, 0, len(kinds))
+	for _, kind := range kinds {
+		if parseKind(kind).version != "" {
+			match, err := lookupKind(client, kind)
+			if err != nil {
+				return nil, err
+			}
+			matches = append(matches, match)
+			continue
+		}
+
+		if preferred == nil {
+			var err error
+			if preferred, err = serverPreferredResources(client); err != nil {
+				return nil, err
+			}
+		}
+		match, err := findGVRByKind(preferred, kind)
+		if err != nil {
+			return nil, err
+		}
+		matches = append(matches, match)
+	}
+	return matches, nil
+}
+
+// lookupKind resolves a kind that names a version against the resources of that group version.
+func lookupKind(client Client, kind string) (*gvrMatch, error) {
+	query := parseKind(kind)
+	discoClient, err := client.DiscoClient()
+	if err != nil {
+		return nil, fmt.Errorf("failed to create discovery client: %w", err)
+	}
+	groupVersion := schema.GroupVersion{Group: query.group, Version: query.version}.String()
+	apiResourceList, err := discoClient.ServerResourcesForGroupVersion(groupVersion)
+	if apierrors.IsNotFound(err) || errors.Is(err, memory.ErrCacheNotFound) {
+		return nil, &kindNotFoundError{kind: kind}
+	}
+	if err != nil {
+		return nil, fmt.Errorf("cannot find resource '%s': %w", kind, err)
+	}
+	return findGVRByKind([]*metav1.APIResourceList{apiResourceList}, kind)
+}
@@ -0,0 +1,117 @@
+package tools
+
+import (
+	"errors"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/client-go/discovery"
+)
+
+// staleDiscoveryClient serves stale resources until it is invalidated, or fails with err
+type staleDiscoveryClient struct {
+	fakeDiscoveryClient
+	fresh         []*metav1.APIResourceList
+	err           error
+	invalidations int
+}
+
+func (s *staleDiscoveryClient) ServerPreferredResources() ([]*metav1.APIResourceList, error) {
+	if s.err != nil {
+		return nil, s.err
+	}
+	return s.fakeDiscoveryClient.ServerPreferredResources()
+}
+
+func (s *staleDiscoveryClient) Invalidate() {
+	s.invalidations++
+	s.apiResourceLists = s.fresh
+}
+
+type staleDiscoveryKubernetesClient struct {
+	FakeKubernetesClient
+	disco *staleDiscoveryClient
+}
+
+func (c staleDiscoveryKubernetesClient) DiscoClient() (discovery.DiscoveryInterface, error) {
+	return c.disco, nil
+}
+
+func (c staleDiscoveryKubernetesClient) InvalidateDiscovery() {
+	c.disco.Invalidate()
+}
+
+func TestResolveKind(t *testing.T) {
+	pods := &metav1.APIResourceList{
+		GroupVersion: "v1",
+		APIResources: []metav1.APIResource{{Kind: "Pod", Name: "pods", Namespaced: true}},
+	}
+	widgets := &metav1.APIResourceList{
+		GroupVersion: "example.com/v1",
+		APIResources: []metav1.APIResource{{Kind: "Widget", Name: "widgets", Namespaced: true}},
+	}
+	disco := &staleDiscoveryClient{
+		fakeDiscoveryClient: fakeDiscoveryClient{apiResourceLists: []*metav1.APIResourceList{pods}},
+		fresh:               []*metav1.APIResourceList{pods, widgets},
+	}
+	client := staleDiscoveryKubernetesClient{disco: disco}
+
+	// Hits are served from the cache
+	match, err := resolveKind(client, "Pod")
+	assert.NoError(t, err)
+	assert.Equal(t, "pods", match.apiRes.Name)
+	assert.Equal(t, 0, disco.invalidations)
+
+	// A miss invalidates the cache and finds a newly installed CRD
+	match, err = resolveKind(client, "Widget")
+	assert.NoError(t, err)
+	assert.Equal(t, "example.com/v1", match.groupVersion)
+	assert.Equal(t, 1, disco.invalidations)
+
+	// Unknown kinds are retried once and then reported
+	_, err = resolveKind(client, "Gadget")
+	assert.Error(t, err)
+	assert.Contains(t, err.Error(), "cannot find resource 'Gadget'")
+	assert.Equal(t, 2, disco.invalidations)
+
+	// Discovery failures, such as a forbidden or unreachable API server, do not refresh discovery
+	disco.err = errors.New("the server has asked for the client to provide credentials")
+	_, err = resolveKind(client, "Gadget")
+	assert.ErrorContains(t, err, "failed to discover resources")
+	assert.Equal(t, 2, disco.invalidations)
+}
+
+func TestResolveKind_Qualified(t *testing.T) {
+	disco := &staleDiscoveryClient{
+		fakeDiscoveryClient: fakeDiscoveryClient{apiResourceLists: []*metav1.APIResourceList{
+			{
+				GroupVersion: "example.com/v1",
+				APIResources: []metav1.APIResource{{Kind: "Widget", Name: "widgets", Namespaced: true}},
+			},
+			{
+				GroupVersion: "other.io/v1alpha1",
+				APIResources: []metav1.APIResource{{Kind: "Widget", Name: "widgets", Namespaced: false}},
+			},
+		}},
+	}
+	client := staleDiscoveryKubernetesClient{disco: disco}
+
+	// Ambiguous kinds are reported without refreshing discovery
+	_, err := resolveKind(client, "Widget")
+	assert.Error(t, err)
+	assert.Contains(t, err.Error(), "widgets.example.com, widgets.other.io")
+	assert.Equal(t, 0, disco.invalidations)
+
+	match, err := resolveKind(client, "widgets.other.io")
+	assert.NoError(t, err)
+	assert.Equal(t, "other.io/v1alpha1", match.groupVersion)
+
+	match, err = resolveKind(client, "example.com/v1/Widget")
+	assert.NoError(t, err)
+	assert.Equal(t, "example.com/v1", match.groupVersion)
+
+	_, err = resolveKind(client, "example.com/v2/Widget")
+	assert.Error(t, err)
+	assert.Contains(t, err.Error(), "cannot find resource 'example.com/v2/Widget'")
+}
@@ -0,0 +1,141 @@
+package tools
+
+import (
+	"fmt"
+	"strings"
+
+	"k8s.io/client-go/util/jsonpath"
+)
+
+// fieldProjection reduces objects to the values at a set of JSONPath expressions.
+type fieldProjection struct {
+	fields  []string
+	parsers []*jsonpath.JSONPath
+}
+
+// newFieldProjection parses fields, each a JSONPath expression such as ".spec.nodeName" or
+// "{.spec.containers[*].image}". The braces and the leading dot may be omitted, like in
+// kubectl's custom-columns.
+func newFieldProjection(fields []string) (*fieldProjection, error) {
+	projection := &fieldProjection{fields: fields}
+	for _, field := range fields {
+		parser, err := parseFieldPath(field)
+		if err != nil {
+			return nil, fmt.Errorf("invalid field %q: %w", field, err)
+		}
+		projection.parsers = append(projection.parsers, parser)
+	}
+	return projection, nil
+}
+
+// parseFieldPath parses a JSONPath expression in the relaxed syntax of the "fields" argument.
+// Missing keys evaluate to no results rather than an error.
+func parseFieldPath(field string) (*jsonpath.JSONPath, error) {
+	parser := jsonpath.New(field).AllowMissingKeys(true)
+	if err := parser.Parse(relaxedJSONPath(field)); err != nil {
+		return nil, err
+	}
+	return parser, nil
+}
+
+// fieldValues returns the values field matches in obj.
+func fieldValues(parser *jsonpath.JSONPath, obj map[string]any) ([]any, error) {
+	results, err := parser.FindResults(obj)
+	if err != nil {
+		return nil, err
+	}
+	var values []any
+	for _, result := range results {
+		for _, value := range result {
+			values = append(values, value.Interface())
+		}
+	}
+	return values, nil
+}
+
+// relaxedJSONPath wraps a bare path in a JSONPath template.
+func relaxedJSONPath(field string) string {
+	if strings.HasPrefix(field, "{") {
+		return field
+	}
+	if !strings.HasPrefix(field, ".") {
+		field = "." + field
+	}
+	return "{" + field + "}"
+}
+
+// project returns the name and namespace of obj together with the value of each field, keyed by
+// the field as requested. Fields matching nothing are null and fields matching several values,
+// such as wildcards, are arrays.
+func (p *fieldProjection) project(obj map[string]any) (map[string]any, error) {
+	projected := map[string]any{}
+	if metadata, ok := obj["metadata"].(map[string]any); ok {
+		for _, key := range []string{"name", "namespace"} {
+			if value, found := metadata[key]; found {
+				projected[key] = value
+			}
+		}
+	}
+
+	for i, parser := range p.parsers {
+		values, err := fieldValues(parser, obj)
+		if err != nil {
+			return nil, fmt.Errorf("failed to evaluate field %q: %w", p.fields[i], err)
+		}
+		switch len(values) {
+		case 0:
+			projected[p.fields[i]] = nil
+		case 1:
+			projected[p.fields[i]] = values[0]
+		default:
+			projected[p.fields[i]] = values
+		}
+	}
+	return projected, nil
+}
+
+// projectFields redacts each object and projects it to the given fields. It returns the projected
+// objects and the number of redacted values.
+func (o Options) projectFields(objects []map[string]any, projection *fieldProjection) ([]map[string]any, int, error) {
+	projected := make([]map[string]any, 0, len(objects))
+	redactions := 0
+	for _, obj := range objects {
+		redactions += o.redactObject(obj)
+		item, err := projection.project(obj)
+		if err != nil {
+			return nil, 0, err
+		}
+		projected = append(projected, item)
+	}
+	return projected, redactions, nil
+}
+
+// parseFields reads the "fields" argument, given as an array of JSONPath expressions or a single
+// expression, and checks that every expression parses.
+func parseFields(args map[string]any) ([]string, error) {
+	var fields []string
+	switch value := args["fields"].(type) {
+	case nil:
+		return nil, nil
+	case string:
+		if value != "" {
+			fields = []string{value}
+		}
+	case []any:
+		for _, field := range value {
+			s, ok := field.(string)
+			if !ok || s == "" {
+				return nil, fmt.Errorf("invalid fields: every field must be a non-empty string")
+			}
+			fields = append(fields, s)
+		}
+	case []string:
+		fields = value
+	default:
+		return nil, fmt.Errorf("invalid fields: must be an array of JSONPath expressions")
+	}
+	if _, err := newFieldProjection(fields); err != nil {
+		return nil, err
+	}
+	return fields, nil
+}
@@ -0,0 +1,145 @@
+package tools
+
+import (
+	"context"
+	"testing"
+
+	"github.com/mark3labs/mcp-go/mcp"
+	"github.com/stretchr/testify/assert"
+	"k8s.io/apimachinery/pkg/apis/meta/v1/unstructured"
+	"k8s.io/apimachinery/pkg/runtime"
+)
+
+// newTestPod returns a pod with two containers for field projection tests
+func newTestPod(namespace, name, node string) map[string]any {
+	pod := newUnstructured("v1", "Pod", namespace, name)
+	pod.Object["spec"] = map[string]any{
+		"nodeName": node,
+		"containers": []any{
+			map[string]any{"name": "app", "image": "app:1.0", "env": []any{
+				map[string]any{"name": "DB_PASSWORD", "value": "hunter2"},
+			}},
+			map[string]any{"name": "sidecar", "image": "proxy:2.1"},
+		},
+	}
+	pod.Object["status"] = map[string]any{
+		"conditions": []any{
+			map[string]any{"type": "Ready", "status": "True"},
+		},
+	}
+	return pod.Object
+}
+
+func TestFieldProjection(t *testing.T) {
+	testCases := []struct {
+		name     string
+		fields   []string
+		expected map[string]any
+	}{
+		{
+			name:   "bare path",
+			fields: []string{"spec.nodeName"},
+			expected: map[string]any{
+				"name": "web", "namespace": "default",
+				"spec.nodeName": "node-1",
+			},
+		},
+		{
+			name:   "template and wildcard",
+			fields: []string{".spec.containers[*].image", "{.spec.containers[0].name}"},
+			expected: map[string]any{
+				"name": "web", "namespace": "default",
+				".spec.containers[*].image":  []any{"app:1.0", "proxy:2.1"},
+				"{.spec.containers[0].name}": "app",
+			},
+		},
+		{
+			name:   "filter",
+			fields: []string{`.status.conditions[?(@.type=="Ready")].status`},
+			expected: map[string]any{
+				"name": "web", "namespace": "default",
+				`.status.conditions[?(@.type=="Ready")].status`: "True",
+			},
+		},
+		{
+			name:   "missing field",
+			fields: []string{".spec.replicas"},
+			expected: map[string]any{
+				"name": "web", "namespace": "default",
+				".spec.replicas": nil,
+			},
+		},
+	}
+
+	for _, tc := range testCases {
+		t.Run(tc.name, func(t *testing.T) {
+			projection, err := newFieldProjection(tc.fields)
+			assert.NoError(t, err)
+			actual, err := projection.project(newTestPod("default", "web", "node-1"))
+			assert.NoError(t, err)
+			assert.Equal(t, tc.expected, actual)
+		})
+	}
+}
+
+func TestParseFields(t *testing.T) {
+	fields, err := parseFields(map[string]any{"fields": []any{".spec.nodeName", "{.metadata.labels}"}})
+	assert.NoError(t, err)
+	assert.Equal(t, []string{".spec.nodeName", "{.metadata.labels}"}, fields)
+
+	fields, err = parseFields(map[string]any{"fields": ".spec.nodeName"})
+	assert.NoError(t, err)
+	assert.Equal(t, []string{".spec.nodeName"}, fields)
+
+	fields, err = parseFields(map[string]any{})
+	assert.NoError(t, err)
+	assert.Nil(t, fields)
+
+	_, err = parseFields(map[string]any{"fields": []any{".spec.containers[*"}})
+	assert.Error(t, err)
+	assert.Contains(t, err.Error(), "invalid field")
+
+	_, err = parseFields(map[string]any{"fields": []any{float64(1)}})
+	assert.Error(t, err)
+}
+
+func TestFields_Tools(t *testing.T) {
+	web := newTestPod("default", "web", "node-1")
+	api := newTestPod("default", "api", "node-2")
+	client := FakeObjectsClient{
+		resources: namespacedTestResources,
+		objects:   []runtime.Object{&unstructured.Unstructured{Object: web}, &unstructured.Unstructured{Object: api}},
+	}
+	multiClient := NewFakeMultiClusterClient(client)
+
+	list := NewListTool(multiClient, DefaultOptions())
+	result, err := list.Handler(context.Background(), mcp.CallToolRequest{Params: mcp.CallToolParams{Arguments: map[string]any{
+		"kind":   "Pod",
+		"fields": []any{".spec.nodeName"},
+	}}})
+	assert.NoError(t, err)
+	assert.Equal(t,
+		`{"items":[{".spec.nodeName":"node-2","name":"api","namespace":"default"},{".spec.nodeName":"node-1","name":"web","namespace":"default"}]}`,
+		result.Content[0].(mcp.TextContent).Text)
+
+	_, err = list.Handler(context.Background(), mcp.CallToolRequest{Params: mcp.CallToolParams{Arguments: map[string]any{
+		"kind":   "Pod",
+		"fields": []any{".spec.nodeName"},
+		"output": "table",
+	}}})
+	assert.Error(t, err)
+
+	// Values are redacted before projection, since the projected keys no longer identify them
+	describe := NewDescribeTool(multiClient, DefaultOptions())
+	result, err = describe.Handler(context.Background(), mcp.CallToolRequest{Params: mcp.CallToolParams{Arguments: map[string]any{
+		"kind":      "Pod",
+		"name":      "web",
+		"namespace": "default",
+		"fields":    []any{".spec.containers[0].env[0].value"},
+	}}})
+	assert.NoError(t, err)
+	assert.Equal(t,
+		`{".spec.containers[0].env[0].value":"[REDACTED]","name":"web","namespace":"default"}`,
+		result.Content[0].(mcp.TextContent).Text)
+	assert.Equal(t, 1, result.Meta[redactionsMetaKey])
+}
@@ -2,9 +2,11 @@ package tools
 
 import (
 	"context"
-	"encoding/json"
 	"errors"
 	"fmt"
+	"slices"
+	"sort"
+	"strconv"
 	"strings"
 
 	"github.com/kkb0318/kubernetes-mcp/src/validation"
@@ -17,15 +19,40 @@ import (
 
 // ListResourcesInput represents the input parameters for listing Kubernetes resources.
 type ListResourcesInput struct {
-	Context        string `json:"context,omitempty"`
-	Kind           string `json:"kind"`
-	GroupFilter    string `json:"groupFilter,omitempty"`
-	Namespace      string `json:"namespace,omitempty"`
-	LabelSelector  string `json:"labelSelector,omitempty"`
-	FieldSelector  string `json:"fieldSelector,omitempty"`
-	Limit          int64  `json:"limit,omitempty"`
-	TimeoutSeconds int64  `json:"timeoutSeconds,omitempty"`
-	ShowDetails    bool   `json:"showDetails,omitempty"`
+	Context        string   `json:"context,omitempty"`
+	Contexts       []string `json:"contexts,omitempty"`
+	Kind           string   `json:"kind"`
+	Kinds          []string `json:"kinds,omitempty"`
+	GroupFilter    string   `json:"groupFilter,omitempty"`
+	Namespace      string   `json:"namespace,omitempty"`
+	LabelSelector  string   `json:"labelSelector,omitempty"`
+	FieldSelector  string   `json:"fieldSelector,omitempty"`
+	Limit          int64    `json:"limit,omitempty"`
+	Continue       string   `json:"continue,omitempty"`
+	TimeoutSeconds int64    `json:"timeoutSeconds,omitempty"`
+	ShowDetails    bool     `json:"showDetails,omitempty"`
+	Raw            bool     `json:"raw,omitempty"`
+	Output         string   `json:"output,omitempty"`
+	Fields         []string `json:"fields,omitempty"`
+	SortBy         string   `json:"sortBy,omitempty"`
+	Order          string   `json:"order,omitempty"`
+	Summary        bool     `json:"summary,omitempty"`
+	GroupBy        string   `json:"groupBy,omitempty"`
+	Instances      bool     `json:"instances,omitempty"`
+}
+
+// ListResourcesResult is the response of list_resources. Continue is set when more resources
+// are available; passing it back as the "continue" argument returns the next page.
+type ListResourcesResult struct {
+	Items              any    `json:"items"`
+	Continue           string `json:"continue,omitempty"`
+	RemainingItemCount *int64 `json:"remainingItemCount,omitempty"`
+
+	objectNames []string
+}
+
+func (r ListResourcesResult) names() []string {
+	return r.objectNames
 }
 
 // ResourceWithStatus represents a resource with its status information extracted.
@@ -39,11 +66,12 @@ type ResourceWithStatus struct {
 // ListTool provides functionality to list Kubernetes resources by kind.
 type ListTool struct {
 	multiClient MultiClusterClientInterface
+	opts        Options
 }
 
-// NewListTool creates a new ListTool instance with the provided MultiClusterClient.
-func NewListTool(multiClient MultiClusterClientInterface) ListTool {
-	return ListTool{multiClient: multiClient}
+// NewListTool creates a new ListTool instance with the provided MultiClusterClient and options.
+func NewListTool(multiClient MultiClusterClientInterface, opts Options) ListTool {
+	return ListTool{multiClient: multiClient, opts: opts}
 }
 
 // Tool returns the MCP tool definition for listing Kubernetes resources.
@@ -53,12 +81,16 @@ func (l ListTool) Tool() mcp.Tool {
 		mcp.WithString("context",
 			mcp.Description("Kubernetes context name from kubeconfig to use for this request (leave empty for current context)"),
 		),
+		withContexts(),
 		mcp.WithString("kind",
-			mcp.Description("Kind of the Kubernetes resource, e.g., Pod, Deployment, Service, ConfigMap, or any CRD. Use 'all' with groupFilter to discover all resource types for a project."),
+			mcp.Description("Kind of the Kubernetes resource, e.g., Pod, Deployment, Service, ConfigMap, or any CRD. Qualify it with its API group as kind.group (e.g., 'certificates.cert-manager.io') or group/version/kind (e.g., 'apps/v1/Deployment') when it exists in several groups. Several kinds can be listed at once, comma-separated (e.g., 'deploy,sts,po,svc'). Use 'all' with groupFilter to discover all resource types for a project."),
 		),
 		mcp.WithString("groupFilter",
 			mcp.Description("Filter by API group substring to discover all resources from a project (e.g., 'flux' for FluxCD, 'argo' for ArgoCD, 'istio' for Istio). When used with kind='all', returns all matching resource types."),
 		),
+		mcp.WithBoolean("instances",
+			mcp.Description("With groupFilter and kind='all', list the resources of every matching type instead of only the types, grouped by kind. A type that cannot be listed, e.g. because it is forbidden, is reported with its error (default: false)"),
+		),
 		mcp.WithString("namespace",
 			mcp.Description("Kubernetes namespace to list resources from (leave empty for all namespaces, use 'default' for default namespace)"),
 		),
@@ -69,129 +101,162 @@ func (l ListTool) Tool() mcp.Tool {
 			mcp.Description("Filter resources by field selector (e.g., 'metadata.name=my-pod', 'spec.nodeName=node1')"),
 		),
 		mcp.WithNumber("limit",
-			mcp.Description("Maximum number of resources to return (useful for large clusters, default: no limit)"),
+			mcp.Description("Maximum number of resources to return (useful for large clusters, default: no limit). When more are available, the response includes a continue token. The limit applies before resources in namespaces blocked by the server's policy are removed, so a page can hold fewer resources, and remainingItemCount is omitted when listing across namespaces"),
+		),
+		mcp.WithString("continue",
+			mcp.Description("Continue token from a previous response to fetch the next page, with the same kind, namespace and selectors"),
 		),
 		mcp.WithNumber("timeoutSeconds",
-			mcp.Description("Timeout for the list operation in seconds (default: 30)"),
+			mcp.Description(fmt.Sprintf("Timeout for the list operation in seconds (default: %d)", l.opts.DefaultTimeoutSeconds)),
 		),
 		mcp.WithBoolean("showDetails",
 			mcp.Description("Return complete resource objects instead of just name and status (default: false)"),
 		),
+		withRaw(),
+		mcp.WithArray("fields",
+			mcp.Description("JSONPath expressions to return for each resource instead of the whole resource, e.g. ['.spec.nodeName', '.spec.containers[*].image']. Each resource is returned with its name, namespace and the value of every field"),
+			mcp.Items(map[string]any{"type": "string"}),
+		),
+		mcp.WithString("sortBy",
+			mcp.Description("JSONPath expression to sort the resources by, e.g. '.metadata.creationTimestamp' or '.status.containerStatuses[0].restartCount'. With limit, only the returned page is sorted"),
+		),
+		withOrder(),
+		mcp.WithBoolean("summary",
+			mcp.Description("Return only the number of resources in each namespace instead of the resources, fetching them page by page (default: false)"),
+		),
+		mcp.WithString("groupBy",
+			mcp.Description("With summary, also count the resources by the value of this JSONPath expression, e.g. '.status.phase' or '.status.conditions[?(@.type==\"Ready\")].status'"),
+		),
+		withOutput(),
 	)
 }
 
 // Handler processes requests to list Kubernetes resources by kind and namespace.
 func (l ListTool) Handler(ctx context.Context, req mcp.CallToolRequest) (*mcp.CallToolResult, error) {
-	input, err := parseAndValidateListParams(req.Params.Arguments)
+	input, err := parseAndValidateListParams(req.GetArguments(), l.opts)
 	if err != nil {
 		return nil, err
 	}
+	if len(input.Contexts) > 0 {
+		return l.opts.forEachContext(ctx, l.multiClient, req, input.Contexts, l.Handler)
+	}
 
 	// Get the appropriate client for the context
-	client, err := l.multiClient.GetClient(input.Context)
+	client, err := l.multiClient.GetClient(ctx, input.Context)
 	if err != nil {
 		return nil, fmt.Errorf("failed to get client for context '%s': %w", input.Context, err)
 	}
 
 	// Handle groupFilter functionality for discovering resources
 	if input.GroupFilter != "" {
-		if input.Kind == "all" || input.Kind == "" {
+		if input.Instances {
+			// Fan-out mode: list the resources of every type in the group
+			return l.listGroup(ctx, client, input)
+		} else if input.Kind == "all" || input.Kind == "" {
 			// Discovery mode: return all resource types for the group
-			return l.handleGroupDiscovery(client, input.GroupFilter)
+			return l.handleGroupDiscovery(client, input)
 		} else {
 			// Filter mode: find specific kind within the group
 			return l.handleGroupFilteredList(ctx, client, input)
 		}
 	}
 
+	if len(input.Kinds) > 1 {
+		return l.listKinds(ctx, client, input)
+	}
+
 	// Original functionality for specific kind
-	gvrMatch, err := l.discoverResourceByKind(client, input.Kind)
+	gvrMatch, err := resolveKind(client, input.Kind)
 	if err != nil {
 		return nil, err
 	}
 
-	if input.ShowDetails {
-		// Return full resource details (complete objects)
-		resources, err := l.listResourceDetails(ctx, client, gvrMatch, input)
-		if err != nil {
-			return nil, err
-		}
-		out, err := json.Marshal(resources)
-		if err != nil {
-			return nil, fmt.Errorf("failed to marshal resource details: %w", err)
-		}
-		return mcp.NewToolResultText(string(out)), nil
-	} else {
-		// Default: Return resources with status information
-		resourcesWithStatus, err := l.listResourcesWithStatus(ctx, client, gvrMatch, input)
-		if err != nil {
-			return nil, err
-		}
-		out, err := json.Marshal(resourcesWithStatus)
-		if err != nil {
-			return nil, fmt.Errorf("failed to marshal resources with status: %w", err)
-		}
-		return mcp.NewToolResultText(string(out)), nil
+	return l.listResources(ctx, client, gvrMatch, input)
+}
+
+// GroupDiscoveryResult is the result of list_resources with groupFilter and kind 'all': the
+// resource types in the API groups matching the filter.
+type GroupDiscoveryResult struct {
+	GroupFilter     string           `json:"groupFilter"`
+	DiscoveredTypes []DiscoveredType `json:"discoveredTypes"`
+	TotalFound      int              `json:"totalFound"`
+	Message         string           `json:"message"`
+}
+
+// DiscoveredType describes one resource type found by group discovery.
+type DiscoveredType struct {
+	Kind       string   `json:"kind"`
+	Group      string   `json:"group"`
+	Resource   string   `json:"resource"`
+	Namespaced bool     `json:"namespaced"`
+	ShortNames []string `json:"shortNames"`
+	Verbs      []string `json:"verbs"`
+	Categories []string `json:"categories"`
+
+	// name is the resource.group name, e.g. "kustomizations.kustomize.toolkit.fluxcd.io"
+	name string
+}
+
+// table prints the discovered types like 'kubectl api-resources'.
+func (r GroupDiscoveryResult) table() textTable {
+	t := textTable{headers: []string{"name", "shortnames", "apiversion", "namespaced", "kind"}}
+	for _, dt := range r.DiscoveredTypes {
+		t.rows = append(t.rows, []string{dt.Resource, strings.Join(dt.ShortNames, ","), dt.Group, strconv.FormatBool(dt.Namespaced), dt.Kind})
 	}
+	t.footer = []string{r.Message}
+	return t
 }
 
-// handleGroupDiscovery returns all available resource types for a given group filter
-func (l ListTool) handleGroupDiscovery(client Client, groupFilter string) (*mcp.CallToolResult, error) {
-	discoClient, err := client.DiscoClient()
-	if err != nil {
-		return nil, fmt.Errorf("failed to create discovery client: %w", err)
+// names prints the discovered types like 'kubectl api-resources -o name'.
+func (r GroupDiscoveryResult) names() []string {
+	names := make([]string, 0, len(r.DiscoveredTypes))
+	for _, dt := range r.DiscoveredTypes {
+		names = append(names, dt.name)
 	}
+	return names
+}
 
-	apiResourceLists, err := discoClient.ServerPreferredResources()
+// handleGroupDiscovery returns all available resource types for a given group filter
+func (l ListTool) handleGroupDiscovery(client Client, input *ListResourcesInput) (*mcp.CallToolResult, error) {
+	apiResourceLists, err := serverPreferredResources(client)
 	if err != nil {
-		return nil, fmt.Errorf("failed to discover resources: %w", err)
+		return nil, err
 	}
 
-	matches, err := findGVRsByGroupSubstring(apiResourceLists, groupFilter)
+	matches, err := findGVRsByGroupSubstring(apiResourceLists, input.GroupFilter)
 	if err != nil {
 		return nil, fmt.Errorf("failed to find resources by group substring: %w", err)
 	}
 
+	result := GroupDiscoveryResult{
+		GroupFilter:     input.GroupFilter,
+		DiscoveredTypes: make([]DiscoveredType, 0, len(matches)),
+		TotalFound:      len(matches),
+		Message:         fmt.Sprintf("Found %d resource types matching group filter '%s'", len(matches), input.GroupFilter),
+	}
 	if len(matches) == 0 {
-		return mcp.NewToolResultText(fmt.Sprintf(`{"message": "No resources found for group filter '%s'", "availableResources": []}`, groupFilter)), nil
+		result.Message = fmt.Sprintf("No resources found for group filter '%s'", input.GroupFilter)
 	}
-
-	// Format the discovered resource types
-	discoveredTypes := make([]map[string]any, 0)
 	for _, match := range matches {
-		discoveredTypes = append(discoveredTypes, map[string]any{
-			"kind":       match.apiRes.Kind,
-			"group":      match.groupVersion,
-			"resource":   match.apiRes.Name,
-			"namespaced": match.namespaced,
-			"shortNames": match.apiRes.ShortNames,
+		result.DiscoveredTypes = append(result.DiscoveredTypes, DiscoveredType{
+			Kind:       match.apiRes.Kind,
+			Group:      match.groupVersion,
+			Resource:   match.apiRes.Name,
+			Namespaced: match.namespaced,
+			ShortNames: match.apiRes.ShortNames,
+			Verbs:      match.apiRes.Verbs,
+			Categories: match.apiRes.Categories,
+			name:       gvrString(*match.ToGroupVersionResource()),
 		})
 	}
-
-	result := map[string]any{
-		"groupFilter":     groupFilter,
-		"discoveredTypes": discoveredTypes,
-		"totalFound":      len(matches),
-		"message":         fmt.Sprintf("Found %d resource types matching group filter '%s'", len(matches), groupFilter),
-	}
-
-	out, err := json.Marshal(result)
-	if err != nil {
-		return nil, fmt.Errorf("failed to marshal discovery result: %w", err)
-	}
-	return mcp.NewToolResultText(string(out)), nil
+	return l.opts.formatOutput(result, input.Output, 0)
 }
 
 // handleGroupFilteredList lists resources of a specific kind within a filtered group
 func (l ListTool) handleGroupFilteredList(ctx context.Context, client Client, input *ListResourcesInput) (*mcp.CallToolResult, error) {
-	discoClient, err := client.DiscoClient()
-	if err != nil {
-		return nil, fmt.Errorf("failed to create discovery client: %w", err)
-	}
-
-	apiResourceLists, err := discoClient.ServerPreferredResources()
+	apiResourceLists, err := serverPreferredResources(client)
 	if err != nil {
-		return nil, fmt.Errorf("failed to discover resources: %w", err)
+		return nil, err
 	}
 
 	// First find all resources in the group
@@ -225,47 +290,95 @@ func (l ListTool) handleGroupFilteredList(ctx context.Context, client Client, in
 	}
 
 	// Now list the resources using the found GVR
-	if input.ShowDetails {
-		resources, err := l.listResourceDetails(ctx, client, gvrMatch, input)
+	return l.listResources(ctx, client, gvrMatch, input)
+}
+
+// listResources lists one page of resources matching the given GVR and returns them either as
+// complete objects or with their status, together with the continue token for the next page.
+func (l ListTool) listResources(ctx context.Context, client Client, gvrMatch *gvrMatch, input *ListResourcesInput) (*mcp.CallToolResult, error) {
+	value, redactions, err := l.list(ctx, client, gvrMatch, input)
+	if err != nil {
+		return nil, err
+	}
+	return l.opts.formatOutput(value, input.Output, redactions)
+}
+
+// list returns the resources matching the given GVR as a value for formatOutput: a ListSummary
+// in summary mode, a textTable for table output and a ListResourcesResult otherwise. It also
+// returns the number of values already redacted.
+func (l ListTool) list(ctx context.Context, client Client, gvrMatch *gvrMatch, input *ListResourcesInput) (any, int, error) {
+	if input.Summary {
+		return l.listSummary(ctx, client, gvrMatch, input)
+	}
+
+	if input.Output == outputTable {
+		table, err := l.listTable(ctx, client, gvrMatch, input)
 		if err != nil {
-			return nil, err
+			return nil, 0, err
 		}
-		out, err := json.Marshal(resources)
-		if err != nil {
-			return nil, fmt.Errorf("failed to marshal resource details: %w", err)
+		return table, 0, nil
+	}
+
+	unstructList, err := l.listObjects(ctx, client, gvrMatch, input)
+	if err != nil {
+		return nil, 0, err
+	}
+	if input.SortBy != "" {
+		if err := sortObjects(unstructList.Items, input.SortBy, input.Order == orderDesc); err != nil {
+			return nil, 0, err
 		}
-		return mcp.NewToolResultText(string(out)), nil
-	} else {
-		resourcesWithStatus, err := l.listResourcesWithStatus(ctx, client, gvrMatch, input)
+	}
+
+	result := ListResourcesResult{
+		Continue:           unstructList.GetContinue(),
+		RemainingItemCount: unstructList.GetRemainingItemCount(),
+	}
+	gvr := gvrMatch.ToGroupVersionResource()
+	for _, item := range unstructList.Items {
+		result.objectNames = append(result.objectNames, objectName(item.GetKind(), *gvr, item.GetName()))
+	}
+	redactions := 0
+	if len(input.Fields) > 0 {
+		// Return only the requested fields of each resource
+		projection, err := newFieldProjection(input.Fields)
 		if err != nil {
-			return nil, err
+			return nil, 0, err
+		}
+		objects := make([]map[string]any, 0, len(unstructList.Items))
+		for _, item := range unstructList.Items {
+			objects = append(objects, item.Object)
 		}
-		out, err := json.Marshal(resourcesWithStatus)
+		result.Items, redactions, err = l.opts.projectFields(objects, projection)
 		if err != nil {
-			return nil, fmt.Errorf("failed to marshal resources with status: %w", err)
+			return nil, 0, err
+		}
+	} else if input.ShowDetails {
+		// Return full resource details (complete objects)
+		items := make([]map[string]any, 0, len(unstructList.Items))
+		for _, item := range unstructList.Items {
+			if !input.Raw {
+				l.opts.Pruner.Object(item.Object)
+			}
+			items = append(items, item.Object)
 		}
-		return mcp.NewToolResultText(string(out)), nil
+		result.Items = items
+	} else {
+		// Default: Return resources with status information
+		result.Items = l.resourcesWithStatus(unstructList)
 	}
+
+	return result, redactions, nil
 }
 
-// discoverResourceByKind discovers and returns the GroupVersionResource match for a given kind.
-func (l ListTool) discoverResourceByKind(client Client, kind string) (*gvrMatch, error) {
-	discoClient, err := client.DiscoClient()
+// listObjects lists the resources matching the given GVR and input parameters, enforcing the namespace policy.
+func (l ListTool) listObjects(ctx context.Context, client Client, gvrMatch *gvrMatch, input *ListResourcesInput) (*unstructured.UnstructuredList, error) {
+	gvr := gvrMatch.ToGroupVersionResource()
+	access, err := l.checkList(gvrMatch, input)
 	if err != nil {
-		return nil, fmt.Errorf("failed to create discovery client: %w", err)
+		return nil, err
 	}
 
-	apiResourceLists, err := discoClient.ServerPreferredResources()
-	if err != nil {
-		return nil, fmt.Errorf("failed to discover resources: %w", err)
-	}
-
-	return findGVRByKind(apiResourceLists, kind)
-}
-
-// listResourceDetails retrieves full details of all resources matching the given GVR and input parameters.
-func (l ListTool) listResourceDetails(ctx context.Context, client Client, gvrMatch *gvrMatch, input *ListResourcesInput) (any, error) {
-	ri, err := client.ResourceInterface(*gvrMatch.ToGroupVersionResource(), gvrMatch.namespaced, input.Namespace)
+	ri, err := client.ResourceInterface(*gvr, gvrMatch.namespaced, input.Namespace)
 	if err != nil {
 		return nil, fmt.Errorf("failed to create resource interface: %w", err)
 	}
@@ -276,9 +389,54 @@ func (l ListTool) listResourceDetails(ctx context.Context, client Client, gvrMat
 		return nil, fmt.Errorf("failed to list resources: %w", err)
 	}
 
+	isNamespaceResource := gvr.Group == "" && gvr.Resource == "namespaces"
+	access.filterList(unstructList, isNamespaceResource)
+	if access.filtersList(gvrMatch.namespaced, input.Namespace, isNamespaceResource) {
+		unstructList.SetRemainingItemCount(nil)
+	}
+
+	if l.opts.Resources.MetadataOnly(*gvr) {
+		for i := range unstructList.Items {
+			unstructList.Items[i] = *metadataOnlyObject(&unstructList.Items[i])
+		}
+	}
+
 	return unstructList, nil
 }
 
+// listTable lists one page of resources as the server-side Table kubectl get shows, including
+// the additionalPrinterColumns of CRDs.
+func (l ListTool) listTable(ctx context.Context, client Client, gvrMatch *gvrMatch, input *ListResourcesInput) (textTable, error) {
+	access, err := l.checkList(gvrMatch, input)
+	if err != nil {
+		return textTable{}, err
+	}
+	gvr := gvrMatch.ToGroupVersionResource()
+	return serverTable(ctx, client, serverTableRequest{
+		gvr:          *gvr,
+		namespaced:   gvrMatch.namespaced,
+		namespace:    input.Namespace,
+		listOptions:  l.buildListOptions(input),
+		access:       access,
+		metadataOnly: l.opts.Resources.MetadataOnly(*gvr),
+	})
+}
+
+// checkList enforces the resource and namespace policies for listing gvrMatch and returns the
+// namespace access used to filter the listed items.
+func (l ListTool) checkList(gvrMatch *gvrMatch, input *ListResourcesInput) (namespaceAccess, error) {
+	access := l.opts.namespaceAccess(l.multiClient, input.Context)
+	if err := l.opts.checkResource(*gvrMatch.ToGroupVersionResource()); err != nil {
+		return access, err
+	}
+	if gvrMatch.namespaced {
+		if err := access.check(input.Namespace); err != nil {
+			return access, err
+		}
+	}
+	return access, nil
+}
+
 // buildListOptions creates metav1.ListOptions from the input parameters.
 func (l ListTool) buildListOptions(input *ListResourcesInput) metav1.ListOptions {
 	listOptions := metav1.ListOptions{
@@ -289,32 +447,22 @@ func (l ListTool) buildListOptions(input *ListResourcesInput) metav1.ListOptions
 	if input.Limit > 0 {
 		listOptions.Limit = input.Limit
 	}
+	listOptions.Continue = input.Continue
 
 	listOptions.TimeoutSeconds = &input.TimeoutSeconds
 
 	return listOptions
 }
 
-// listResourcesWithStatus retrieves resources and extracts their status information.
-func (l ListTool) listResourcesWithStatus(ctx context.Context, client Client, gvrMatch *gvrMatch, input *ListResourcesInput) ([]ResourceWithStatus, error) {
-	ri, err := client.ResourceInterface(*gvrMatch.ToGroupVersionResource(), gvrMatch.namespaced, input.Namespace)
-	if err != nil {
-		return nil, fmt.Errorf("failed to create resource interface: %w", err)
-	}
-
-	listOptions := l.buildListOptions(input)
-	unstructList, err := ri.List(ctx, listOptions)
-	if err != nil {
-		return nil, fmt.Errorf("failed to list resources: %w", err)
-	}
-
-	var resourcesWithStatus []ResourceWithStatus
+// resourcesWithStatus extracts the status information of the listed resources.
+func (l ListTool) resourcesWithStatus(unstructList *unstructured.UnstructuredList) []ResourceWithStatus {
+	resourcesWithStatus := make([]ResourceWithStatus, 0, len(unstructList.Items))
 	for _, item := range unstructList.Items {
 		resourceWithStatus := l.extractResourceStatus(&item)
 		resourcesWithStatus = append(resourcesWithStatus, resourceWithStatus)
 	}
 
-	return resourcesWithStatus, nil
+	return resourcesWithStatus
 }
 
 // extractResourceStatus extracts the status section from a resource.
@@ -334,13 +482,18 @@ func (l ListTool) extractResourceStatus(obj *unstructured.Unstructured) Resource
 }
 
 // parseAndValidateListParams validates and extracts parameters from request arguments.
-func parseAndValidateListParams(args map[string]any) (*ListResourcesInput, error) {
+func parseAndValidateListParams(args map[string]any, opts Options) (*ListResourcesInput, error) {
 	input := &ListResourcesInput{}
 
-	// Optional: context
+	// Optional: context, or contexts to run the request in each of them
 	if context, ok := args["context"].(string); ok {
 		input.Context = context
 	}
+	contexts, err := parseContexts(args)
+	if err != nil {
+		return nil, err
+	}
+	input.Contexts = contexts
 
 	// Optional: groupFilter
 	if groupFilter, ok := args["groupFilter"].(string); ok {
@@ -350,8 +503,20 @@ func parseAndValidateListParams(args map[string]any) (*ListResourcesInput, error
 	// Kind: Required unless groupFilter is used for discovery
 	if kindVal, ok := args["kind"].(string); ok && kindVal != "" {
 		input.Kind = kindVal
-		if err := validation.ValidateKind(input.Kind); err != nil {
-			return nil, fmt.Errorf("invalid kind: %w", err)
+		// Several kinds can be given comma-separated, like 'kubectl get deploy,sts'
+		kinds := splitKinds(kindVal)
+		if len(kinds) == 0 {
+			return nil, fmt.Errorf("invalid kind: %q", kindVal)
+		}
+		for _, kind := range kinds {
+			if err := validation.ValidateKind(kind); err != nil {
+				return nil, fmt.Errorf("invalid kind: %w", err)
+			}
+		}
+		if len(kinds) > 1 {
+			input.Kinds = kinds
+		} else {
+			input.Kind = kinds[0]
 		}
 	} else if input.GroupFilter == "" {
 		return nil, errors.New("kind must be provided when groupFilter is not specified")
@@ -386,12 +551,16 @@ func parseAndValidateListParams(args map[string]any) (*ListResourcesInput, error
 		input.Limit = int64(limit)
 	}
 
+	// Optional: continue
+	if continueToken, ok := args["continue"].(string); ok {
+		input.Continue = continueToken
+	}
+
 	// Optional: timeoutSeconds
 	if timeoutSeconds, ok := args["timeoutSeconds"].(float64); ok && timeoutSeconds > 0 {
 		input.TimeoutSeconds = int64(timeoutSeconds)
 	} else {
-		// Default timeout of 30 seconds
-		input.TimeoutSeconds = 30
+		input.TimeoutSeconds = opts.DefaultTimeoutSeconds
 	}
 
 	// Optional: showDetails
@@ -399,6 +568,91 @@ func parseAndValidateListParams(args map[string]any) (*ListResourcesInput, error
 		input.ShowDetails = showDetails
 	}
 
+	// Optional: raw
+	if raw, ok := args["raw"].(bool); ok {
+		input.Raw = raw
+	}
+
+	// Optional: output
+	output, err := parseOutput(args)
+	if err != nil {
+		return nil, err
+	}
+	input.Output = output
+
+	// Optional: fields
+	fields, err := parseFields(args)
+	if err != nil {
+		return nil, err
+	}
+	if len(fields) > 0 && (output == outputTable || output == outputName) {
+		return nil, fmt.Errorf("fields cannot be combined with output '%s'", output)
+	}
+	input.Fields = fields
+
+	// Optional: sortBy and order
+	if sortBy, ok := args["sortBy"].(string); ok && sortBy != "" {
+		if _, err := newSortKey(sortBy); err != nil {
+			return nil, err
+		}
+		if output == outputTable {
+			return nil, fmt.Errorf("sortBy cannot be combined with output '%s'", output)
+		}
+		input.SortBy = sortBy
+	}
+	order, err := parseOrder(args)
+	if err != nil {
+		return nil, err
+	}
+	if order != "" && input.SortBy == "" {
+		return nil, errors.New("order requires sortBy")
+	}
+	input.Order = order
+
+	// Optional: instances
+	if instances, ok := args["instances"].(bool); ok && instances {
+		switch {
+		case input.GroupFilter == "" || (input.Kind != "all" && input.Kind != ""):
+			return nil, errors.New("instances requires groupFilter with kind 'all'")
+		case input.Continue != "":
+			return nil, errors.New("continue cannot be combined with instances, list the kind to page through on its own")
+		}
+		input.Instances = true
+	}
+
+	if len(input.Kinds) > 1 {
+		switch {
+		case input.GroupFilter != "":
+			return nil, errors.New("groupFilter cannot be combined with several kinds")
+		case input.Continue != "":
+			return nil, errors.New("continue cannot be combined with several kinds, list the kind to page through on its own")
+		}
+	}
+
+	// Optional: summary and groupBy
+	if summary, ok := args["summary"].(bool); ok {
+		input.Summary = summary
+	}
+	if groupBy, ok := args["groupBy"].(string); ok && groupBy != "" {
+		if !input.Summary {
+			return nil, errors.New("groupBy requires summary")
+		}
+		if _, err := parseFieldPath(groupBy); err != nil {
+			return nil, fmt.Errorf("invalid groupBy %q: %w", groupBy, err)
+		}
+		input.GroupBy = groupBy
+	}
+	if input.Summary {
+		switch {
+		case input.ShowDetails || len(input.Fields) > 0 || input.SortBy != "":
+			return nil, errors.New("summary cannot be combined with showDetails, fields or sortBy")
+		case input.Limit > 0 || input.Continue != "":
+			return nil, errors.New("summary counts every resource and cannot be combined with limit or continue")
+		case output == outputName:
+			return nil, fmt.Errorf("summary cannot be combined with output '%s'", output)
+		}
+	}
+
 	return input, nil
 }
 
@@ -460,6 +714,7 @@ func (f *gvrMatch) ToGroupVersionResource() *schema.GroupVersionResource {
 }
 
 // findGVRsByGroupSubstring finds all resources whose group contains the specified substring (case-insensitive).
+// Subresources and resources that do not support the list verb are skipped, since they cannot be listed.
 func findGVRsByGroupSubstring(apiResourceLists []*metav1.APIResourceList, groupSubstring string) (gvrMatchList, error) {
 	target := strings.ToLower(groupSubstring)
 	var matches gvrMatchList
@@ -472,6 +727,9 @@ func findGVRsByGroupSubstring(apiResourceLists []*metav1.APIResourceList, groupS
 			continue
 		}
 		for _, r := range apiResList.APIResources {
+			if !isListable(&r) {
+				continue
+			}
 			matches = append(matches, newGvrMatch(&r, gv, r.Namespaced))
 		}
 	}
@@ -479,47 +737,122 @@ func findGVRsByGroupSubstring(apiResourceLists []*metav1.APIResourceList, groupS
 	return matches, nil
 }
 
-// findGVRByKind finds a resource by matching against plural name, Kind, or short names (case-insensitive).
+// isListable reports whether apiRes is a resource, not a subresource like "deployments/status",
+// that supports the list verb.
+func isListable(apiRes *metav1.APIResource) bool {
+	return !strings.Contains(apiRes.Name, "/") && slices.Contains(apiRes.Verbs, "list")
+}
+
+// kindQuery is a parsed kind argument. Besides a bare kind, plural name or short name, the kind
+// may be qualified with its API group as "kind.group" (optionally "kind.version.group"), or given
+// as "group/version/kind" or "version/kind" for the core group.
+type kindQuery struct {
+	name    string
+	group   string
+	version string
+	// qualified is set when the query names the API group; an empty group then means the core group
+	qualified bool
+	// qualifier is the part after the first dot of a "kind.group" query
+	qualifier string
+}
+
+// parseKind splits a kind argument into its name and optional group and version.
+func parseKind(kind string) kindQuery {
+	if parts := strings.Split(kind, "/"); len(parts) > 1 {
+		query := kindQuery{name: parts[len(parts)-1], version: parts[len(parts)-2], qualified: true}
+		if len(parts) > 2 {
+			query.group = parts[0]
+		}
+		return query
+	}
+	if name, qualifier, found := strings.Cut(kind, "."); found {
+		return kindQuery{name: name, qualifier: strings.ToLower(qualifier)}
+	}
+	return kindQuery{name: kind}
+}
+
+// matchesGroupVersion reports whether resources of the given group and version satisfy the query's
+// group qualification.
+func (q kindQuery) matchesGroupVersion(group, version string) bool {
+	switch {
+	case q.qualifier != "":
+		return q.qualifier == group || q.qualifier == version+"."+group
+	case q.qualified:
+		return q.group == group && q.version == version
+	default:
+		return true
+	}
+}
+
+// matchesResource reports whether the query's name is the resource's plural name, Kind or one of
+// its short names (case-insensitive).
+func (q kindQuery) matchesResource(r *metav1.APIResource) bool {
+	if strings.EqualFold(r.Name, q.name) || strings.EqualFold(r.Kind, q.name) {
+		return true
+	}
+	for _, sn := range r.ShortNames {
+		if strings.EqualFold(sn, q.name) {
+			return true
+		}
+	}
+	return false
+}
+
+// ambiguousKindError is returned when a bare kind matches resources in several API groups.
+type ambiguousKindError struct {
+	kind       string
+	candidates []string
+}
+
+func (e *ambiguousKindError) Error() string {
+	return fmt.Sprintf("kind '%s' is ambiguous, it matches %s; qualify it with its API group, e.g. '%s'",
+		e.kind, strings.Join(e.candidates, ", "), e.candidates[0])
+}
+
+// findGVRByKind finds a resource by matching against plural name, Kind, or short names (case-insensitive),
+// restricted to the API group and version the kind is qualified with, if any.
+// Like kubectl, a bare kind that matches several groups resolves to the core group; otherwise an
+// ambiguousKindError lists the candidates.
 func findGVRByKind(apiResourceLists []*metav1.APIResourceList, kind string) (*gvrMatch, error) {
-	target := strings.ToLower(kind)
-	var found *gvrMatch
+	query := parseKind(kind)
+	var matches gvrMatchList
 
 	for _, apiResList := range apiResourceLists {
 		if apiResList == nil {
 			continue
 		}
-		gv := apiResList.GroupVersion
+		gv, err := schema.ParseGroupVersion(apiResList.GroupVersion)
+		if err != nil || !query.matchesGroupVersion(gv.Group, gv.Version) {
+			continue
+		}
 
 		for _, r := range apiResList.APIResources {
-			nameLower := strings.ToLower(r.Name)
-			kindLower := strings.ToLower(r.Kind)
-
-			if nameLower == target || kindLower == target {
-				found = newGvrMatch(&r, gv, r.Namespaced)
-				break
+			// Subresources such as pods/status share the Kind of their parent
+			if strings.Contains(r.Name, "/") || !query.matchesResource(&r) {
+				continue
 			}
-
-			for _, sn := range r.ShortNames {
-				if strings.ToLower(sn) == target {
-					found = newGvrMatch(&r, gv, r.Namespaced)
-					break
-				}
-			}
-			if found != nil {
-				break
-			}
-		}
-
-		if found != nil {
+			matches = append(matches, newGvrMatch(&r, apiResList.GroupVersion, r.Namespaced))
 			break
 		}
 	}
 
-	if found == nil {
-		return nil, fmt.Errorf("cannot find resource '%s'", kind)
+	switch len(matches) {
+	case 0:
+		return nil, &kindNotFoundError{kind: kind}
+	case 1:
+		return matches[0], nil
 	}
-	if found.ToGroupVersionResource() == nil {
-		return nil, fmt.Errorf("cannot find resource '%s'", kind)
+
+	for _, match := range matches {
+		if match.ToGroupVersionResource().Group == "" {
+			return match, nil
+		}
+	}
+
+	candidates := make([]string, 0, len(matches))
+	for _, gvr := range matches.ToGroupVersionResources() {
+		candidates = append(candidates, gvrString(*gvr))
 	}
-	return found, nil
+	sort.Strings(candidates)
+	return nil, &ambiguousKindError{kind: kind, candidates: candidates}
 }
@@ -11,11 +11,12 @@ import (
 // ListContextsTool は Kubernetes の context 一覧を返すツールです
 type ListContextsTool struct {
 	multiClient MultiClusterClientInterface
+	opts        Options
 }
 
 // NewListContextsTool は新しい ListContextsTool インスタンスを作成します
-func NewListContextsTool(multiClient MultiClusterClientInterface) *ListContextsTool {
-	return &ListContextsTool{multiClient: multiClient}
+func NewListContextsTool(multiClient MultiClusterClientInterface, opts Options) *ListContextsTool {
+	return &ListContextsTool{multiClient: multiClient, opts: opts}
 }
 
 // Tool は MCP ツールの定義を返します
@@ -34,6 +35,8 @@ func (t *ListContextsTool) Tool() mcp.Tool {
 type ContextInfo struct {
 	Name      string `json:"name"`
 	IsCurrent bool   `json:"is_current"`
+	// Source は context が定義されている kubeconfig ファイルです
+	Source string `json:"source,omitempty"`
 }
 
 // ListContextsResponse は list_contexts の応答を表す構造体です
@@ -56,11 +59,9 @@ func (t *ListContextsTool) Handler(ctx context.Context, req mcp.CallToolRequest)
 
 	// Build response
 	contextInfos := make([]ContextInfo, len(contexts))
-	for i, contextName := range contexts {
-		contextInfos[i] = ContextInfo{
-			Name:      contextName,
-			IsCurrent: contextName == currentContext,
-		}
+	for i, contextInfo := range contexts {
+		contextInfo.IsCurrent = contextInfo.Name == currentContext
+		contextInfos[i] = contextInfo
 	}
 
 	response := ListContextsResponse{
@@ -75,7 +76,7 @@ func (t *ListContextsTool) Handler(ctx context.Context, req mcp.CallToolRequest)
 		return nil, fmt.Errorf("error marshaling response: %w", err)
 	}
 
-	return mcp.NewToolResultText(string(jsonBytes)), nil
+	return t.opts.toolResult(jsonBytes)
 }
 
 // Compile-time verification that ListContextsTool implements Tools interface
@@ -12,28 +12,33 @@ import (
 // FakeListContextsMultiClusterClient implements MultiClusterClientInterface for testing list_contexts functionality
 type FakeListContextsMultiClusterClient struct {
 	contexts       []string
+	sources        map[string]string
 	currentContext string
 	listError      error
 }
 
-func (f *FakeListContextsMultiClusterClient) ListContexts() ([]string, error) {
+func (f *FakeListContextsMultiClusterClient) ListContexts() ([]ContextInfo, error) {
 	if f.listError != nil {
 		return nil, f.listError
 	}
-	return f.contexts, nil
+	contexts := make([]ContextInfo, 0, len(f.contexts))
+	for _, name := range f.contexts {
+		contexts = append(contexts, ContextInfo{Name: name, Source: f.sources[name]})
+	}
+	return contexts, nil
 }
 
 func (f *FakeListContextsMultiClusterClient) GetDefaultContext() string {
 	return f.currentContext
 }
 
-func (f *FakeListContextsMultiClusterClient) GetClient(context string) (Client, error) {
+func (f *FakeListContextsMultiClusterClient) GetClient(ctx context.Context, contextName string) (Client, error) {
 	return nil, nil
 }
 
 func TestListContextsTool_Tool(t *testing.T) {
 	multiClient := &FakeListContextsMultiClusterClient{}
-	tool := NewListContextsTool(multiClient)
+	tool := NewListContextsTool(multiClient, DefaultOptions())
 
 	mcpTool := tool.Tool()
 
@@ -47,6 +52,7 @@ func TestListContextsTool_Handler(t *testing.T) {
 	testCases := []struct {
 		name           string
 		contexts       []string
+		sources        map[string]string
 		currentContext string
 		listError      error
 		expectedErr    bool
@@ -113,6 +119,22 @@ func TestListContextsTool_Handler(t *testing.T) {
 				}
 			},
 		},
+		{
+			name:     "contexts from multiple kubeconfig files",
+			contexts: []string{"eks", "gke"},
+			sources: map[string]string{
+				"eks": "/home/user/.kube/eks",
+				"gke": "/home/user/.kube/gke",
+			},
+			currentContext: "gke",
+			expectedErr:    false,
+			validate: func(t *testing.T, response *ListContextsResponse) {
+				assert.Equal(t, []ContextInfo{
+					{Name: "eks", IsCurrent: false, Source: "/home/user/.kube/eks"},
+					{Name: "gke", IsCurrent: true, Source: "/home/user/.kube/gke"},
+				}, response.Contexts)
+			},
+		},
 		{
 			name:        "error listing contexts",
 			listError:   assert.AnError,
@@ -124,11 +146,12 @@ func TestListContextsTool_Handler(t *testing.T) {
 		t.Run(tc.name, func(t *testing.T) {
 			multiClient := &FakeListContextsMultiClusterClient{
 				contexts:       tc.contexts,
+				sources:        tc.sources,
 				currentContext: tc.currentContext,
 				listError:      tc.listError,
 			}
 
-			tool := NewListContextsTool(multiClient)
+			tool := NewListContextsTool(multiClient, DefaultOptions())
 
 			req := &mcp.CallToolRequest{}
 			req.Params.Arguments = map[string]interface{}{}
@@ -218,7 +241,7 @@ func TestContextInfo_JSONTags(t *testing.T) {
 
 func TestNewListContextsTool(t *testing.T) {
 	multiClient := &FakeListContextsMultiClusterClient{}
-	tool := NewListContextsTool(multiClient)
+	tool := NewListContextsTool(multiClient, DefaultOptions())
 
 	assert.NotNil(t, tool)
 	assert.Equal(t, multiClient, tool.multiClient)
@@ -226,7 +249,7 @@ func TestNewListContextsTool(t *testing.T) {
 
 func TestListContextsTool_ImplementsInterface(t *testing.T) {
 	multiClient := &FakeListContextsMultiClusterClient{}
-	tool := NewListContextsTool(multiClient)
+	tool := NewListContextsTool(multiClient, DefaultOptions())
 
 	// Verify that ListContextsTool implements Tools interface
 	var _ Tools = tool
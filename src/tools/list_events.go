@@ -1,9 +1,11 @@
 package tools
 
 import (
+	"cmp"
 	"context"
-	"encoding/json"
+	"errors"
 	"fmt"
+	"slices"
 	"strings"
 	"time"
 
@@ -13,21 +15,32 @@ import (
 	corev1 "k8s.io/api/core/v1"
 	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
 	"k8s.io/apimachinery/pkg/fields"
+	"k8s.io/apimachinery/pkg/util/duration"
 )
 
 // ListEventsInput represents the input parameters for listing Kubernetes events.
 type ListEventsInput struct {
-	Context        string `json:"context,omitempty"`
-	Namespace      string `json:"namespace,omitempty"`
-	Object         string `json:"object,omitempty"`
-	EventType      string `json:"eventType,omitempty"`
-	Reason         string `json:"reason,omitempty"`
-	Since          string `json:"since,omitempty"`
-	SinceTime      string `json:"sinceTime,omitempty"`
-	Limit          int64  `json:"limit,omitempty"`
-	TimeoutSeconds int64  `json:"timeoutSeconds,omitempty"`
+	Context        string   `json:"context,omitempty"`
+	Contexts       []string `json:"contexts,omitempty"`
+	Namespace      string   `json:"namespace,omitempty"`
+	Object         string   `json:"object,omitempty"`
+	EventType      string   `json:"eventType,omitempty"`
+	Reason         string   `json:"reason,omitempty"`
+	Since          string   `json:"since,omitempty"`
+	SinceTime      string   `json:"sinceTime,omitempty"`
+	Limit          int64    `json:"limit,omitempty"`
+	TimeoutSeconds int64    `json:"timeoutSeconds,omitempty"`
+	SortBy         string   `json:"sortBy,omitempty"`
+	Order          string   `json:"order,omitempty"`
+	Output         string   `json:"output,omitempty"`
 }
 
+// Event fields list_events can sort by.
+const (
+	eventsSortByLastTimestamp = "lastTimestamp"
+	eventsSortByCount         = "count"
+)
+
 // EventInfo represents formatted event information for better readability.
 type EventInfo struct {
 	FirstTimestamp metav1.Time `json:"firstTimestamp"`
@@ -39,16 +52,56 @@ type EventInfo struct {
 	Message        string      `json:"message"`
 	Source         string      `json:"source,omitempty"`
 	Namespace      string      `json:"namespace,omitempty"`
+	Name           string      `json:"name,omitempty"`
+}
+
+// ListEventsResult is the result of list_events.
+type ListEventsResult struct {
+	Events    []EventInfo    `json:"events"`
+	Total     int            `json:"total"`
+	Namespace string         `json:"namespace"`
+	Filters   map[string]any `json:"filters"`
+}
+
+// table prints the events like 'kubectl get events', with a NAMESPACE column across namespaces.
+func (r ListEventsResult) table() textTable {
+	var t textTable
+	if r.Namespace == "" {
+		t.headers = append(t.headers, "Namespace")
+	}
+	t.headers = append(t.headers, "Last Seen", "Type", "Reason", "Object", "Message")
+	for _, event := range r.Events {
+		var row []string
+		if r.Namespace == "" {
+			row = append(row, event.Namespace)
+		}
+		lastSeen := "<unknown>"
+		if !event.LastTimestamp.IsZero() {
+			lastSeen = duration.HumanDuration(time.Since(event.LastTimestamp.Time))
+		}
+		row = append(row, lastSeen, event.Type, event.Reason, event.Object, event.Message)
+		t.rows = append(t.rows, row)
+	}
+	return t
+}
+
+func (r ListEventsResult) names() []string {
+	names := make([]string, 0, len(r.Events))
+	for _, event := range r.Events {
+		names = append(names, "event/"+event.Name)
+	}
+	return names
 }
 
 // ListEventsTool provides functionality to list Kubernetes events with advanced filtering.
 type ListEventsTool struct {
 	multiClient MultiClusterClientInterface
+	opts        Options
 }
 
-// NewListEventsTool creates a new ListEventsTool instance with the provided MultiClusterClient.
-func NewListEventsTool(multiClient MultiClusterClientInterface) *ListEventsTool {
-	return &ListEventsTool{multiClient: multiClient}
+// NewListEventsTool creates a new ListEventsTool instance with the provided MultiClusterClient and options.
+func NewListEventsTool(multiClient MultiClusterClientInterface, opts Options) *ListEventsTool {
+	return &ListEventsTool{multiClient: multiClient, opts: opts}
 }
 
 // Tool returns the MCP tool definition for listing Kubernetes events.
@@ -58,6 +111,7 @@ func (l *ListEventsTool) Tool() mcp.Tool {
 		mcp.WithString("context",
 			mcp.Description("Kubernetes context name from kubeconfig to use for this request (leave empty for current context)"),
 		),
+		withContexts(),
 		mcp.WithString("namespace",
 			mcp.Description("Kubernetes namespace to list events from (leave empty for all namespaces, use 'default' for default namespace)"),
 		),
@@ -77,27 +131,41 @@ func (l *ListEventsTool) Tool() mcp.Tool {
 			mcp.Description("Return events after a specific time (RFC3339 format, e.g., 2025-06-20T10:00:00Z) (optional)"),
 		),
 		mcp.WithNumber("limit",
-			mcp.Description("Maximum number of events to return (default: 100, use 0 for no limit)"),
+			mcp.Description(fmt.Sprintf("Maximum number of events to return (default: %d, use 0 for no limit)", l.opts.DefaultEventsLimit)),
 		),
 		mcp.WithNumber("timeoutSeconds",
-			mcp.Description("Timeout for the list operation in seconds (default: 30)"),
+			mcp.Description(fmt.Sprintf("Timeout for the list operation in seconds (default: %d)", l.opts.DefaultTimeoutSeconds)),
+		),
+		mcp.WithString("sortBy",
+			mcp.Description("Sort events by 'lastTimestamp' or 'count'. Without it, events are returned in the order the API server lists them"),
+			mcp.Enum(eventsSortByLastTimestamp, eventsSortByCount),
 		),
+		withOrder(),
+		withOutput(),
 	)
 }
 
 // Handler processes requests to list Kubernetes events with filtering options.
 func (l *ListEventsTool) Handler(ctx context.Context, req mcp.CallToolRequest) (*mcp.CallToolResult, error) {
-	input, err := l.parseAndValidateEventsParams(req.Params.Arguments)
+	input, err := l.parseAndValidateEventsParams(req.GetArguments())
 	if err != nil {
 		return nil, fmt.Errorf("failed to parse and validate events params: %w", err)
 	}
+	if len(input.Contexts) > 0 {
+		return l.opts.forEachContext(ctx, l.multiClient, req, input.Contexts, l.Handler)
+	}
 
 	// Get the appropriate client for the context
-	client, err := l.multiClient.GetClient(input.Context)
+	client, err := l.multiClient.GetClient(ctx, input.Context)
 	if err != nil {
 		return nil, fmt.Errorf("failed to get client for context '%s': %w", input.Context, err)
 	}
 
+	access := l.opts.namespaceAccess(l.multiClient, input.Context)
+	if err := access.check(input.Namespace); err != nil {
+		return nil, err
+	}
+
 	clientset, err := client.Clientset()
 	if err != nil {
 		return nil, fmt.Errorf("failed to get clientset: %w", err)
@@ -119,30 +187,28 @@ func (l *ListEventsTool) Handler(ctx context.Context, req mcp.CallToolRequest) (
 	}
 
 	// Filter events based on input parameters
-	filteredEvents := l.filterEvents(eventList.Items, input)
+	filteredEvents := l.filterEvents(filterEventsByNamespace(eventList.Items, access), input)
 
 	// Convert to EventInfo format for better readability
 	eventInfos := l.convertToEventInfos(filteredEvents)
-
-	result := map[string]any{
-		"events":    eventInfos,
-		"total":     len(eventInfos),
-		"namespace": input.Namespace,
-		"filters": map[string]any{
-			"object":         input.Object,
-			"eventType":      input.EventType,
-			"reason":         input.Reason,
-			"since":          input.Since,
-			"sinceTime":      input.SinceTime,
-		},
+	if input.SortBy != "" {
+		sortEventInfos(eventInfos, input.SortBy, input.Order == orderDesc)
 	}
 
-	out, err := json.Marshal(result)
-	if err != nil {
-		return nil, fmt.Errorf("failed to marshal events: %w", err)
+	result := ListEventsResult{
+		Events:    eventInfos,
+		Total:     len(eventInfos),
+		Namespace: input.Namespace,
+		Filters: map[string]any{
+			"object":    input.Object,
+			"eventType": input.EventType,
+			"reason":    input.Reason,
+			"since":     input.Since,
+			"sinceTime": input.SinceTime,
+		},
 	}
 
-	return mcp.NewToolResultText(string(out)), nil
+	return l.opts.formatOutput(result, input.Output, 0)
 }
 
 // buildListOptions creates metav1.ListOptions from the input parameters.
@@ -193,6 +259,20 @@ func (l *ListEventsTool) filterEvents(events []corev1.Event, input *ListEventsIn
 	return filteredEvents
 }
 
+// filterEventsByNamespace drops events from namespaces blocked by the namespace policy.
+func filterEventsByNamespace(events []corev1.Event, access namespaceAccess) []corev1.Event {
+	if access.filter.IsZero() {
+		return events
+	}
+	var allowedEvents []corev1.Event
+	for _, event := range events {
+		if access.allowed(event.Namespace) {
+			allowedEvents = append(allowedEvents, event)
+		}
+	}
+	return allowedEvents
+}
+
 // isEventWithinTimeRange checks if the event falls within the specified time range.
 func (l *ListEventsTool) isEventWithinTimeRange(event *corev1.Event, input *ListEventsInput) bool {
 	var cutoffTime time.Time
@@ -237,6 +317,7 @@ func (l *ListEventsTool) convertToEventInfos(events []corev1.Event) []EventInfo
 			Reason:         event.Reason,
 			Message:        event.Message,
 			Namespace:      event.Namespace,
+			Name:           event.Name,
 		}
 
 		// Format involved object information
@@ -261,14 +342,43 @@ func (l *ListEventsTool) convertToEventInfos(events []corev1.Event) []EventInfo
 	return eventInfos
 }
 
+// sortEventInfos sorts events by their last timestamp or count. Events without a last timestamp,
+// such as those recorded with the events.k8s.io API, use their first timestamp.
+func sortEventInfos(events []EventInfo, sortBy string, descending bool) {
+	slices.SortStableFunc(events, func(a, b EventInfo) int {
+		var c int
+		if sortBy == eventsSortByCount {
+			c = cmp.Compare(a.Count, b.Count)
+		} else {
+			c = eventTime(a).Compare(eventTime(b))
+		}
+		if descending {
+			return -c
+		}
+		return c
+	})
+}
+
+func eventTime(event EventInfo) time.Time {
+	if event.LastTimestamp.IsZero() {
+		return event.FirstTimestamp.Time
+	}
+	return event.LastTimestamp.Time
+}
+
 // parseAndValidateEventsParams validates and extracts parameters from request arguments.
 func (l *ListEventsTool) parseAndValidateEventsParams(args map[string]any) (*ListEventsInput, error) {
 	input := &ListEventsInput{}
 
-	// Optional: context
+	// Optional: context, or contexts to list the events of each of them
 	if context, ok := args["context"].(string); ok && context != "" {
 		input.Context = context
 	}
+	contexts, err := parseContexts(args)
+	if err != nil {
+		return nil, err
+	}
+	input.Contexts = contexts
 
 	if ns, ok := args["namespace"].(string); ok && ns != "" {
 		input.Namespace = ns
@@ -312,14 +422,36 @@ func (l *ListEventsTool) parseAndValidateEventsParams(args map[string]any) (*Lis
 	if limit, ok := args["limit"].(float64); ok && limit >= 0 {
 		input.Limit = int64(limit)
 	} else {
-		input.Limit = 100
+		input.Limit = l.opts.DefaultEventsLimit
 	}
 
 	if timeoutSeconds, ok := args["timeoutSeconds"].(float64); ok && timeoutSeconds > 0 {
 		input.TimeoutSeconds = int64(timeoutSeconds)
 	} else {
-		input.TimeoutSeconds = 30
+		input.TimeoutSeconds = l.opts.DefaultTimeoutSeconds
+	}
+
+	if sortBy, ok := args["sortBy"].(string); ok && sortBy != "" {
+		if sortBy != eventsSortByLastTimestamp && sortBy != eventsSortByCount {
+			return nil, fmt.Errorf("invalid sortBy: must be '%s' or '%s'", eventsSortByLastTimestamp, eventsSortByCount)
+		}
+		input.SortBy = sortBy
+	}
+
+	order, err := parseOrder(args)
+	if err != nil {
+		return nil, err
+	}
+	if order != "" && input.SortBy == "" {
+		return nil, errors.New("order requires sortBy")
+	}
+	input.Order = order
+
+	output, err := parseOutput(args)
+	if err != nil {
+		return nil, err
 	}
+	input.Output = output
 
 	return input, nil
 }
@@ -7,6 +7,7 @@ import (
 	"testing"
 	"time"
 
+	"github.com/kkb0318/kubernetes-mcp/src/policy"
 	"github.com/mark3labs/mcp-go/mcp"
 	"github.com/stretchr/testify/assert"
 	corev1 "k8s.io/api/core/v1"
@@ -15,6 +16,7 @@ import (
 	"k8s.io/apimachinery/pkg/runtime/schema"
 	"k8s.io/client-go/discovery"
 	"k8s.io/client-go/dynamic"
+	"k8s.io/client-go/rest"
 	"k8s.io/client-go/kubernetes"
 )
 
@@ -39,6 +41,10 @@ func (f *FakeEventsClient) RESTMapper() (meta.RESTMapper, error) {
 	return nil, nil
 }
 
+func (f *FakeEventsClient) RESTClient() (rest.Interface, error) {
+	return nil, nil
+}
+
 func (f *FakeEventsClient) ResourceInterface(gvr schema.GroupVersionResource, namespaced bool, ns string) (dynamic.ResourceInterface, error) {
 	return nil, nil
 }
@@ -50,16 +56,10 @@ func TestListEventsTool_Handler_ClientsetError(t *testing.T) {
 	}
 
 	multiClient := NewFakeMultiClusterClient(client)
-	tool := NewListEventsTool(multiClient)
+	tool := NewListEventsTool(multiClient, DefaultOptions())
 
 	req := mcp.CallToolRequest{
-		Params: struct {
-			Name      string         `json:"name"`
-			Arguments map[string]any `json:"arguments,omitempty"`
-			Meta      *struct {
-				ProgressToken mcp.ProgressToken `json:"progressToken,omitempty"`
-			} `json:"_meta,omitempty"`
-		}{
+		Params: mcp.CallToolParams{
 			Arguments: map[string]any{
 				"namespace": "default",
 			},
@@ -117,7 +117,34 @@ func TestParseAndValidateEventsParams(t *testing.T) {
 				assert.Empty(t, input.SinceTime)
 				assert.Equal(t, int64(100), input.Limit)     // Default value
 				assert.Equal(t, int64(30), input.TimeoutSeconds) // Default value
+				assert.Empty(t, input.SortBy)
+			},
+		},
+		{
+			name: "SortByCount",
+			args: map[string]any{
+				"sortBy": "count",
+				"order":  "desc",
 			},
+			expectedErr: false,
+			validate: func(t *testing.T, input *ListEventsInput) {
+				assert.Equal(t, "count", input.SortBy)
+				assert.Equal(t, "desc", input.Order)
+			},
+		},
+		{
+			name: "OrderWithoutSortBy",
+			args: map[string]any{
+				"order": "desc",
+			},
+			expectedErr: true,
+		},
+		{
+			name: "InvalidSortBy",
+			args: map[string]any{
+				"sortBy": "reason",
+			},
+			expectedErr: true,
 		},
 		{
 			name: "InvalidEventType",
@@ -173,7 +200,7 @@ func TestParseAndValidateEventsParams(t *testing.T) {
 		t.Run(tc.name, func(t *testing.T) {
 			client := &FakeEventsClient{}
 			multiClient := NewFakeMultiClusterClient(client)
-	tool := NewListEventsTool(multiClient)
+	tool := NewListEventsTool(multiClient, DefaultOptions())
 			result, err := tool.parseAndValidateEventsParams(tc.args)
 
 			if tc.expectedErr {
@@ -193,7 +220,7 @@ func TestParseAndValidateEventsParams(t *testing.T) {
 func TestListEventsTool_buildListOptions(t *testing.T) {
 	client := &FakeEventsClient{}
 	multiClient := NewFakeMultiClusterClient(client)
-	tool := NewListEventsTool(multiClient)
+	tool := NewListEventsTool(multiClient, DefaultOptions())
 
 	testCases := []struct {
 		name     string
@@ -244,7 +271,7 @@ func TestListEventsTool_buildListOptions(t *testing.T) {
 func TestListEventsTool_filterEvents(t *testing.T) {
 	client := &FakeEventsClient{}
 	multiClient := NewFakeMultiClusterClient(client)
-	tool := NewListEventsTool(multiClient)
+	tool := NewListEventsTool(multiClient, DefaultOptions())
 
 	// Create test events
 	now := time.Now()
@@ -328,7 +355,7 @@ func TestListEventsTool_filterEvents(t *testing.T) {
 func TestListEventsTool_isEventWithinTimeRange(t *testing.T) {
 	client := &FakeEventsClient{}
 	multiClient := NewFakeMultiClusterClient(client)
-	tool := NewListEventsTool(multiClient)
+	tool := NewListEventsTool(multiClient, DefaultOptions())
 
 	now := time.Now()
 	event := &corev1.Event{
@@ -393,7 +420,7 @@ func TestListEventsTool_isEventWithinTimeRange(t *testing.T) {
 func TestListEventsTool_convertToEventInfos(t *testing.T) {
 	client := &FakeEventsClient{}
 	multiClient := NewFakeMultiClusterClient(client)
-	tool := NewListEventsTool(multiClient)
+	tool := NewListEventsTool(multiClient, DefaultOptions())
 
 	now := time.Now()
 	events := []corev1.Event{
@@ -462,7 +489,7 @@ func TestListEventsTool_convertToEventInfos(t *testing.T) {
 func TestListEventsTool_Tool(t *testing.T) {
 	client := &FakeEventsClient{}
 	multiClient := NewFakeMultiClusterClient(client)
-	tool := NewListEventsTool(multiClient)
+	tool := NewListEventsTool(multiClient, DefaultOptions())
 
 	mcpTool := tool.Tool()
 
@@ -507,4 +534,31 @@ func TestEventInfoJSONSerialization(t *testing.T) {
 	assert.Equal(t, eventInfo.Message, deserializedEventInfo.Message)
 	assert.Equal(t, eventInfo.Source, deserializedEventInfo.Source)
 	assert.Equal(t, eventInfo.Namespace, deserializedEventInfo.Namespace)
-}
\ No newline at end of file
+}
+func TestFilterEventsByNamespace(t *testing.T) {
+	namespaces, err := policy.NewNamespacePolicy([]policy.NamespaceRule{{Denied: []string{"kube-*"}}})
+	assert.NoError(t, err)
+	opts := DefaultOptions()
+	opts.Namespaces = namespaces
+	multiClient := NewFakeMultiClusterClient(&FakeEventsClient{})
+
+	events := []corev1.Event{
+		{ObjectMeta: metav1.ObjectMeta{Name: "a", Namespace: "default"}},
+		{ObjectMeta: metav1.ObjectMeta{Name: "b", Namespace: "kube-system"}},
+		{ObjectMeta: metav1.ObjectMeta{Name: "c", Namespace: "app"}},
+	}
+
+	filtered := filterEventsByNamespace(events, opts.namespaceAccess(multiClient, ""))
+	assert.Len(t, filtered, 2)
+	assert.Equal(t, "a", filtered[0].Name)
+	assert.Equal(t, "c", filtered[1].Name)
+
+	assert.Len(t, filterEventsByNamespace(events, DefaultOptions().namespaceAccess(multiClient, "")), 3)
+
+	tool := NewListEventsTool(multiClient, opts)
+	req := mcp.CallToolRequest{Params: mcp.CallToolParams{Arguments: map[string]any{"namespace": "kube-public"}}}
+	result, err := tool.Handler(context.Background(), req)
+	assert.Error(t, err)
+	assert.Contains(t, err.Error(), "namespace 'kube-public' is blocked")
+	assert.Nil(t, result)
+}
@@ -2,9 +2,11 @@ package tools
 
 import (
 	"context"
+	"encoding/json"
 	"os"
 	"testing"
 
+	"github.com/kkb0318/kubernetes-mcp/src/policy"
 	"github.com/mark3labs/mcp-go/mcp"
 	"github.com/stretchr/testify/assert"
 	"k8s.io/apimachinery/pkg/api/meta"
@@ -16,6 +18,7 @@ import (
 	"k8s.io/client-go/dynamic"
 	"k8s.io/client-go/dynamic/fake"
 	"k8s.io/client-go/kubernetes"
+	"k8s.io/client-go/rest"
 	"sigs.k8s.io/yaml"
 
 	appsv1 "k8s.io/api/apps/v1"
@@ -50,6 +53,9 @@ func (f FakeKubernetesClient) Clientset() (*kubernetes.Clientset, error) {
 func (f FakeKubernetesClient) RESTMapper() (meta.RESTMapper, error) {
 	return nil, nil
 }
+func (f FakeKubernetesClient) RESTClient() (rest.Interface, error) {
+	return nil, nil
+}
 func (f FakeKubernetesClient) ResourceInterface(gvr schema.GroupVersionResource, namespaced bool, ns string) (dynamic.ResourceInterface, error) {
 	scheme := runtime.NewScheme()
 	_ = appsv1.AddToScheme(scheme)
@@ -92,7 +98,7 @@ func TestResource(t *testing.T) {
 							Annotations: nil,
 						},
 						Type: "text",
-						Text: "[{\"name\":\"foo-deployment\",\"namespace\":\"default\",\"kind\":\"Deployment\"}]",
+						Text: "{\"items\":[{\"name\":\"foo-deployment\",\"namespace\":\"default\",\"kind\":\"Deployment\"}]}",
 					},
 				},
 			},
@@ -102,7 +108,7 @@ func TestResource(t *testing.T) {
 	for _, tt := range testCases {
 		t.Run(tt.name, func(t *testing.T) {
 			multiClient := NewFakeMultiClusterClient(tt.input)
-			l := NewListTool(multiClient)
+			l := NewListTool(multiClient, DefaultOptions())
 			req := &mcp.CallToolRequest{}
 			req.Params.Arguments = tt.request
 			actual, err := l.Handler(context.TODO(), *req)
@@ -176,6 +182,94 @@ func TestFindGVR(t *testing.T) {
 			},
 			expectingError: false,
 		},
+		{
+			inputKind: "deployments.apps",
+			datapath:  "testdata/apiresources.yaml",
+			expected: &schema.GroupVersionResource{
+				Group:    "apps",
+				Version:  "v1",
+				Resource: "deployments",
+			},
+			expectingError: false,
+		},
+		{
+			inputKind: "HelmRelease.v2.helm.toolkit.fluxcd.io",
+			datapath:  "testdata/apiresources.yaml",
+			expected: &schema.GroupVersionResource{
+				Group:    "helm.toolkit.fluxcd.io",
+				Version:  "v2",
+				Resource: "helmreleases",
+			},
+			expectingError: false,
+		},
+		{
+			inputKind: "apps/v1/Deployment",
+			datapath:  "testdata/apiresources.yaml",
+			expected: &schema.GroupVersionResource{
+				Group:    "apps",
+				Version:  "v1",
+				Resource: "deployments",
+			},
+			expectingError: false,
+		},
+		{
+			inputKind: "v1/Event",
+			datapath:  "testdata/apiresources.yaml",
+			expected: &schema.GroupVersionResource{
+				Group:    "",
+				Version:  "v1",
+				Resource: "events",
+			},
+			expectingError: false,
+		},
+		{
+			inputKind: "events.events.k8s.io",
+			datapath:  "testdata/apiresources.yaml",
+			expected: &schema.GroupVersionResource{
+				Group:    "events.k8s.io",
+				Version:  "v1",
+				Resource: "events",
+			},
+			expectingError: false,
+		},
+		{
+			// Matches the core group and events.k8s.io, the core group wins like in kubectl
+			inputKind: "Event",
+			datapath:  "testdata/apiresources.yaml",
+			expected: &schema.GroupVersionResource{
+				Group:    "",
+				Version:  "v1",
+				Resource: "events",
+			},
+			expectingError: false,
+		},
+		{
+			// Matches networking.k8s.io and two Calico groups, none of them the core group
+			inputKind:      "NetworkPolicy",
+			datapath:       "testdata/apiresources.yaml",
+			expectingError: true,
+		},
+		{
+			inputKind: "networkpolicies.networking.k8s.io",
+			datapath:  "testdata/apiresources.yaml",
+			expected: &schema.GroupVersionResource{
+				Group:    "networking.k8s.io",
+				Version:  "v1",
+				Resource: "networkpolicies",
+			},
+			expectingError: false,
+		},
+		{
+			// Matches projectcalico.org and crd.projectcalico.org
+			inputKind:      "IPPool",
+			datapath:       "testdata/apiresources.yaml",
+			expectingError: true,
+		},
+		{
+			inputKind:      "deployments.extensions",
+			datapath:       "testdata/apiresources.yaml",
+			expectingError: true,
+		},
 	}
 
 	for _, tt := range tests {
@@ -203,6 +297,23 @@ func TestFindGVR(t *testing.T) {
 	}
 }
 
+func TestFindGVRByKind_Ambiguous(t *testing.T) {
+	data, err := os.ReadFile("testdata/apiresources.yaml")
+	assert.NoError(t, err)
+	var apiResLists []*metav1.APIResourceList
+	assert.NoError(t, yaml.Unmarshal(data, &apiResLists))
+
+	_, err = findGVRByKind(apiResLists, "IPPool")
+	var ambiguous *ambiguousKindError
+	assert.ErrorAs(t, err, &ambiguous)
+	assert.Equal(t, []string{"ippools.crd.projectcalico.org", "ippools.projectcalico.org"}, ambiguous.candidates)
+	assert.Contains(t, err.Error(), "qualify it with its API group, e.g. 'ippools.crd.projectcalico.org'")
+
+	actual, err := findGVRByKind(apiResLists, "ippools.projectcalico.org")
+	assert.NoError(t, err)
+	assert.Equal(t, &schema.GroupVersionResource{Group: "projectcalico.org", Version: "v3", Resource: "ippools"}, actual.ToGroupVersionResource())
+}
+
 func TestFindGVRsByGroupSubstring(t *testing.T) {
 	tests := []struct {
 		name           string
@@ -344,6 +455,7 @@ func TestParseAndValidateListParams(t *testing.T) {
 				"labelSelector":  "app=nginx",
 				"fieldSelector":  "metadata.name=my-deployment",
 				"limit":          float64(10),
+				"continue":       "token",
 				"timeoutSeconds": float64(60),
 				"showDetails":    true,
 			},
@@ -353,6 +465,7 @@ func TestParseAndValidateListParams(t *testing.T) {
 				LabelSelector:  "app=nginx",
 				FieldSelector:  "metadata.name=my-deployment",
 				Limit:          10,
+				Continue:       "token",
 				TimeoutSeconds: 60,
 				ShowDetails:    true,
 			},
@@ -374,6 +487,47 @@ func TestParseAndValidateListParams(t *testing.T) {
 			expected:    nil,
 			expectedErr: true,
 		},
+		{
+			name: "GroupQualifiedKind",
+			args: map[string]any{
+				"kind": "certificates.cert-manager.io",
+			},
+			expected: &ListResourcesInput{
+				Kind:           "certificates.cert-manager.io",
+				Namespace:      metav1.NamespaceAll,
+				TimeoutSeconds: 30,
+			},
+			expectedErr: false,
+		},
+		{
+			name: "GroupVersionKind",
+			args: map[string]any{
+				"kind": "cert-manager.io/v1/Certificate",
+			},
+			expected: &ListResourcesInput{
+				Kind:           "cert-manager.io/v1/Certificate",
+				Namespace:      metav1.NamespaceAll,
+				TimeoutSeconds: 30,
+			},
+			expectedErr: false,
+		},
+		{
+			name: "InvalidGroup",
+			args: map[string]any{
+				"kind": "certificates.Cert_Manager",
+			},
+			expected:    nil,
+			expectedErr: true,
+		},
+		{
+			name: "InvalidOutput",
+			args: map[string]any{
+				"kind":   "pods",
+				"output": "wide",
+			},
+			expected:    nil,
+			expectedErr: true,
+		},
 		{
 			name: "WithShowDetails",
 			args: map[string]any{
@@ -392,7 +546,7 @@ func TestParseAndValidateListParams(t *testing.T) {
 
 	for _, tc := range testCases {
 		t.Run(tc.name, func(t *testing.T) {
-			result, err := parseAndValidateListParams(tc.args)
+			result, err := parseAndValidateListParams(tc.args, DefaultOptions())
 
 			if tc.expectedErr {
 				assert.Error(t, err)
@@ -405,10 +559,20 @@ func TestParseAndValidateListParams(t *testing.T) {
 	}
 }
 
+func TestParseAndValidateListParams_ConfiguredDefaults(t *testing.T) {
+	opts := DefaultOptions()
+	opts.DefaultTimeoutSeconds = 120
+
+	result, err := parseAndValidateListParams(map[string]any{"kind": "pods"}, opts)
+
+	assert.NoError(t, err)
+	assert.Equal(t, int64(120), result.TimeoutSeconds)
+}
+
 func TestListTool_Tool(t *testing.T) {
 	client := FakeKubernetesClient{}
 	multiClient := NewFakeMultiClusterClient(client)
-	tool := NewListTool(multiClient)
+	tool := NewListTool(multiClient, DefaultOptions())
 
 	mcpTool := tool.Tool()
 
@@ -419,7 +583,7 @@ func TestListTool_Tool(t *testing.T) {
 func TestExtractResourceStatus(t *testing.T) {
 	client := FakeKubernetesClient{}
 	multiClient := NewFakeMultiClusterClient(client)
-	tool := NewListTool(multiClient)
+	tool := NewListTool(multiClient, DefaultOptions())
 
 	// Create a mock unstructured object with status
 	obj := &unstructured.Unstructured{}
@@ -481,12 +645,14 @@ func TestBuildListOptions(t *testing.T) {
 				LabelSelector:  "app=nginx",
 				FieldSelector:  "metadata.name=test",
 				Limit:          10,
+				Continue:       "token",
 				TimeoutSeconds: 60,
 			},
 			expected: metav1.ListOptions{
 				LabelSelector:  "app=nginx",
 				FieldSelector:  "metadata.name=test",
 				Limit:          10,
+				Continue:       "token",
 				TimeoutSeconds: func() *int64 { v := int64(60); return &v }(),
 			},
 		},
@@ -496,12 +662,13 @@ func TestBuildListOptions(t *testing.T) {
 		t.Run(tc.name, func(t *testing.T) {
 			client := FakeKubernetesClient{}
 			multiClient := NewFakeMultiClusterClient(client)
-			tool := NewListTool(multiClient)
+			tool := NewListTool(multiClient, DefaultOptions())
 			result := tool.buildListOptions(tc.input)
 
 			assert.Equal(t, tc.expected.LabelSelector, result.LabelSelector)
 			assert.Equal(t, tc.expected.FieldSelector, result.FieldSelector)
 			assert.Equal(t, tc.expected.Limit, result.Limit)
+			assert.Equal(t, tc.expected.Continue, result.Continue)
 			if tc.expected.TimeoutSeconds != nil {
 				assert.NotNil(t, result.TimeoutSeconds)
 				assert.Equal(t, *tc.expected.TimeoutSeconds, *result.TimeoutSeconds)
@@ -509,3 +676,205 @@ func TestBuildListOptions(t *testing.T) {
 		})
 	}
 }
+
+func TestListTool_NamespacePolicy(t *testing.T) {
+	namespaces, err := policy.NewNamespacePolicy([]policy.NamespaceRule{
+		{Contexts: []string{"test-context"}, Denied: []string{"kube-*"}},
+	})
+	assert.NoError(t, err)
+	opts := DefaultOptions()
+	opts.Namespaces = namespaces
+
+	client := FakeObjectsClient{
+		resources: namespacedTestResources,
+		objects: []runtime.Object{
+			newUnstructured("v1", "Pod", "default", "web"),
+			newUnstructured("v1", "Pod", "kube-system", "coredns"),
+			newUnstructured("v1", "Namespace", "", "default"),
+			newUnstructured("v1", "Namespace", "", "kube-system"),
+		},
+	}
+	l := NewListTool(NewFakeMultiClusterClient(client), opts)
+
+	testCases := []struct {
+		name          string
+		request       map[string]any
+		expected      string
+		expectedError string
+	}{
+		{
+			name:     "all namespaces are filtered",
+			request:  map[string]any{"kind": "Pod"},
+			expected: `{"items":[{"name":"web","namespace":"default","kind":"Pod"}]}`,
+		},
+		{
+			name:     "namespace objects are filtered by name",
+			request:  map[string]any{"kind": "Namespace"},
+			expected: `{"items":[{"name":"default","kind":"Namespace"}]}`,
+		},
+		{
+			name:          "denied namespace is rejected",
+			request:       map[string]any{"kind": "Pod", "namespace": "kube-system"},
+			expectedError: "namespace 'kube-system' is blocked in context 'test-context'",
+		},
+		{
+			name:     "other contexts are unrestricted",
+			request:  map[string]any{"kind": "Pod", "namespace": "kube-system", "context": "other-context"},
+			expected: `{"items":[{"name":"coredns","namespace":"kube-system","kind":"Pod"}]}`,
+		},
+	}
+
+	for _, tc := range testCases {
+		t.Run(tc.name, func(t *testing.T) {
+			req := mcp.CallToolRequest{Params: mcp.CallToolParams{Arguments: tc.request}}
+			result, err := l.Handler(context.Background(), req)
+			if tc.expectedError != "" {
+				assert.Error(t, err)
+				assert.Contains(t, err.Error(), tc.expectedError)
+				return
+			}
+			assert.NoError(t, err)
+			assert.Equal(t, tc.expected, result.Content[0].(mcp.TextContent).Text)
+		})
+	}
+}
+
+func TestListTool_Pagination(t *testing.T) {
+	client := PagedObjectsClient{FakeObjectsClient{
+		resources: namespacedTestResources,
+		objects: []runtime.Object{
+			newUnstructured("v1", "Pod", "default", "a"),
+			newUnstructured("v1", "Pod", "default", "b"),
+			newUnstructured("v1", "Pod", "default", "c"),
+		},
+	}}
+	l := NewListTool(NewFakeMultiClusterClient(client), DefaultOptions())
+
+	list := func(args map[string]any) ListResourcesResult {
+		t.Helper()
+		result, err := l.Handler(context.Background(), mcp.CallToolRequest{Params: mcp.CallToolParams{Arguments: args}})
+		assert.NoError(t, err)
+		var page ListResourcesResult
+		assert.NoError(t, json.Unmarshal([]byte(result.Content[0].(mcp.TextContent).Text), &page))
+		return page
+	}
+
+	page := list(map[string]any{"kind": "Pod", "limit": float64(2)})
+	assert.Len(t, page.Items, 2)
+	assert.Equal(t, "2", page.Continue)
+	assert.Equal(t, int64(1), *page.RemainingItemCount)
+
+	page = list(map[string]any{"kind": "Pod", "limit": float64(2), "continue": page.Continue, "showDetails": true})
+	assert.Len(t, page.Items, 1)
+	assert.Equal(t, "c", page.Items.([]any)[0].(map[string]any)["metadata"].(map[string]any)["name"])
+	assert.Empty(t, page.Continue)
+	assert.Nil(t, page.RemainingItemCount)
+}
+
+func TestListTool_PaginationNamespacePolicy(t *testing.T) {
+	namespaces, err := policy.NewNamespacePolicy([]policy.NamespaceRule{
+		{Contexts: []string{"test-context"}, Denied: []string{"kube-*"}},
+	})
+	assert.NoError(t, err)
+	opts := DefaultOptions()
+	opts.Namespaces = namespaces
+
+	client := PagedObjectsClient{FakeObjectsClient{
+		resources: namespacedTestResources,
+		objects: []runtime.Object{
+			newUnstructured("v1", "Pod", "apps", "a"),
+			newUnstructured("v1", "Pod", "kube-system", "b"),
+			newUnstructured("v1", "Pod", "team-a", "c"),
+			newUnstructured("v1", "Pod", "team-a", "d"),
+		},
+	}}
+	l := NewListTool(NewFakeMultiClusterClient(client), opts)
+
+	list := func(args map[string]any) ListResourcesResult {
+		t.Helper()
+		result, err := l.Handler(context.Background(), mcp.CallToolRequest{Params: mcp.CallToolParams{Arguments: args}})
+		assert.NoError(t, err)
+		var page ListResourcesResult
+		assert.NoError(t, json.Unmarshal([]byte(result.Content[0].(mcp.TextContent).Text), &page))
+		return page
+	}
+
+	// The page is cut before blocked items are removed, and the server's count of the remaining
+	// items includes blocked ones, so it is not reported.
+	page := list(map[string]any{"kind": "Pod", "limit": float64(2)})
+	assert.Len(t, page.Items, 1)
+	assert.Equal(t, "2", page.Continue)
+	assert.Nil(t, page.RemainingItemCount)
+
+	page = list(map[string]any{"kind": "Pod", "limit": float64(2), "continue": page.Continue})
+	assert.Len(t, page.Items, 2)
+	assert.Empty(t, page.Continue)
+
+	// Within an allowed namespace nothing is filtered, so the count is kept.
+	page = list(map[string]any{"kind": "Pod", "namespace": "team-a", "limit": float64(1)})
+	assert.Len(t, page.Items, 1)
+	if assert.NotNil(t, page.RemainingItemCount) {
+		assert.Equal(t, int64(1), *page.RemainingItemCount)
+	}
+}
+
+func TestFindGVRsByGroupSubstring_SkipsUnlistable(t *testing.T) {
+	matches, err := findGVRsByGroupSubstring(fluxTestResources, "fluxcd")
+	assert.NoError(t, err)
+	assert.Equal(t, []*schema.GroupVersionResource{
+		{Group: "kustomize.toolkit.fluxcd.io", Version: "v1", Resource: "kustomizations"},
+		{Group: "source.toolkit.fluxcd.io", Version: "v1", Resource: "gitrepositories"},
+		{Group: "source.toolkit.fluxcd.io", Version: "v1", Resource: "buckets"},
+	}, matches.ToGroupVersionResources())
+}
+
+func TestListTool_GroupDiscovery(t *testing.T) {
+	client := FakeObjectsClient{resources: fluxTestResources}
+	l := NewListTool(NewFakeMultiClusterClient(client), DefaultOptions())
+
+	result, err := l.Handler(context.Background(), mcp.CallToolRequest{Params: mcp.CallToolParams{Arguments: map[string]any{
+		"kind":        "all",
+		"groupFilter": "kustomize",
+	}}})
+	assert.NoError(t, err)
+	assert.JSONEq(t, `{
+  "groupFilter": "kustomize",
+  "discoveredTypes": [{
+    "kind": "Kustomization",
+    "group": "kustomize.toolkit.fluxcd.io/v1",
+    "resource": "kustomizations",
+    "namespaced": true,
+    "shortNames": ["ks"],
+    "verbs": ["get", "list", "watch"],
+    "categories": ["fluxcd"]
+  }],
+  "totalFound": 1,
+  "message": "Found 1 resource types matching group filter 'kustomize'"
+}`, result.Content[0].(mcp.TextContent).Text)
+
+	result, err = l.Handler(context.Background(), mcp.CallToolRequest{Params: mcp.CallToolParams{Arguments: map[string]any{
+		"groupFilter": "fluxcd",
+		"output":      "table",
+	}}})
+	assert.NoError(t, err)
+	assert.Equal(t, `NAME              SHORTNAMES   APIVERSION                       NAMESPACED   KIND
+kustomizations    ks           kustomize.toolkit.fluxcd.io/v1   true         Kustomization
+gitrepositories                source.toolkit.fluxcd.io/v1      true         GitRepository
+buckets                        source.toolkit.fluxcd.io/v1      true         Bucket
+
+Found 3 resource types matching group filter 'fluxcd'
+`, result.Content[0].(mcp.TextContent).Text)
+
+	result, err = l.Handler(context.Background(), mcp.CallToolRequest{Params: mcp.CallToolParams{Arguments: map[string]any{
+		"groupFilter": "fluxcd",
+		"output":      "name",
+	}}})
+	assert.NoError(t, err)
+	assert.Equal(t, "kustomizations.kustomize.toolkit.fluxcd.io\ngitrepositories.source.toolkit.fluxcd.io\nbuckets.source.toolkit.fluxcd.io\n", result.Content[0].(mcp.TextContent).Text)
+
+	result, err = l.Handler(context.Background(), mcp.CallToolRequest{Params: mcp.CallToolParams{Arguments: map[string]any{
+		"groupFilter": "cert-manager",
+	}}})
+	assert.NoError(t, err)
+	assert.JSONEq(t, `{"groupFilter":"cert-manager","discoveredTypes":[],"totalFound":0,"message":"No resources found for group filter 'cert-manager'"}`, result.Content[0].(mcp.TextContent).Text)
+}
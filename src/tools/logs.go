@@ -28,11 +28,12 @@ type KubectlLogsInput struct {
 // LogTool handles fetching logs based on the input parameters.
 type LogTool struct {
 	multiClient MultiClusterClientInterface
+	opts        Options
 }
 
-// NewLogTool creates a new LogTool with the provided MultiClusterClient.
-func NewLogTool(multiClient MultiClusterClientInterface) *LogTool {
-	return &LogTool{multiClient: multiClient}
+// NewLogTool creates a new LogTool with the provided MultiClusterClient and options.
+func NewLogTool(multiClient MultiClusterClientInterface, opts Options) *LogTool {
+	return &LogTool{multiClient: multiClient, opts: opts}
 }
 
 // Tool returns the MCP tool definition for fetching pod logs.
@@ -47,13 +48,13 @@ func (l *LogTool) Tool() mcp.Tool {
 			mcp.Description("Name of the pod to get logs from"),
 		),
 		mcp.WithString("namespace",
-			mcp.Description("Kubernetes namespace of the pod (defaults to 'default' if not specified)"),
+			mcp.Description(fmt.Sprintf("Kubernetes namespace of the pod (defaults to '%s' if not specified)", l.opts.DefaultNamespace)),
 		),
 		mcp.WithString("container",
 			mcp.Description("Container name within the pod (optional)"),
 		),
 		mcp.WithNumber("tail",
-			mcp.Description("Number of lines to show from the end of the logs (defaults to 100 if not specified, use 0 for all logs)"),
+			mcp.Description(fmt.Sprintf("Number of lines to show from the end of the logs (defaults to %d if not specified, use 0 for all logs)", l.opts.DefaultLogTailLines)),
 		),
 		mcp.WithString("since",
 			mcp.Description("Return logs newer than a relative duration like 5s, 2m, or 3h (optional)"),
@@ -72,17 +73,21 @@ func (l *LogTool) Tool() mcp.Tool {
 
 // Handler fetches logs based on the provided request parameters.
 func (l *LogTool) Handler(ctx context.Context, req mcp.CallToolRequest) (*mcp.CallToolResult, error) {
-	input, err := l.parseAndValidateLogsParams(req.Params.Arguments)
+	input, err := l.parseAndValidateLogsParams(req.GetArguments())
 	if err != nil {
 		return nil, fmt.Errorf("failed to parse and validate list params: %w", err)
 	}
 
 	// Get the appropriate client for the context
-	client, err := l.multiClient.GetClient(input.Context)
+	client, err := l.multiClient.GetClient(ctx, input.Context)
 	if err != nil {
 		return nil, fmt.Errorf("failed to get client for context '%s': %w", input.Context, err)
 	}
 
+	if err := l.opts.namespaceAccess(l.multiClient, input.Context).check(input.Namespace); err != nil {
+		return nil, err
+	}
+
 	clientset, err := client.Clientset()
 	if err != nil {
 		return nil, fmt.Errorf("failed to get clientset: %w", err)
@@ -189,7 +194,7 @@ func (l *LogTool) Handler(ctx context.Context, req mcp.CallToolRequest) (*mcp.Ca
 		return nil, fmt.Errorf("failed to marshal logs: %w", err)
 	}
 
-	return mcp.NewToolResultText(string(out)), nil
+	return l.opts.toolResult(out)
 }
 
 // sinceSeconds parses the 'since' duration string into seconds.
@@ -247,8 +252,7 @@ func (l *LogTool) parseAndValidateLogsParams(args map[string]any) (*KubectlLogsI
 	if tail, ok := args["tail"]; ok && tail != nil {
 		input.Tail = int64(tail.(float64))
 	} else {
-		// Default to 100 lines if not specified
-		input.Tail = 100
+		input.Tail = l.opts.DefaultLogTailLines
 	}
 
 	if since, ok := args["since"]; ok && since != nil {
@@ -268,7 +272,7 @@ func (l *LogTool) parseAndValidateLogsParams(args map[string]any) (*KubectlLogsI
 	}
 
 	if input.Namespace == "" {
-		input.Namespace = metav1.NamespaceDefault
+		input.Namespace = l.opts.DefaultNamespace
 	}
 
 	if input.Name == "" {
@@ -5,12 +5,14 @@ import (
 	"errors"
 	"testing"
 
+	"github.com/kkb0318/kubernetes-mcp/src/policy"
 	"github.com/mark3labs/mcp-go/mcp"
 	"github.com/stretchr/testify/assert"
 	"k8s.io/apimachinery/pkg/api/meta"
 	"k8s.io/apimachinery/pkg/runtime/schema"
 	"k8s.io/client-go/discovery"
 	"k8s.io/client-go/dynamic"
+	"k8s.io/client-go/rest"
 	"k8s.io/client-go/kubernetes"
 )
 
@@ -35,6 +37,10 @@ func (f *FakeLogClient) RESTMapper() (meta.RESTMapper, error) {
 	return nil, nil
 }
 
+func (f *FakeLogClient) RESTClient() (rest.Interface, error) {
+	return nil, nil
+}
+
 func (f *FakeLogClient) ResourceInterface(gvr schema.GroupVersionResource, namespaced bool, ns string) (dynamic.ResourceInterface, error) {
 	return nil, nil
 }
@@ -46,16 +52,10 @@ func TestLogTool_Handler_ClientsetError(t *testing.T) {
 	}
 
 	multiClient := NewFakeMultiClusterClient(client)
-	tool := NewLogTool(multiClient)
+	tool := NewLogTool(multiClient, DefaultOptions())
 
 	req := mcp.CallToolRequest{
-		Params: struct {
-			Name      string         `json:"name"`
-			Arguments map[string]any `json:"arguments,omitempty"`
-			Meta      *struct {
-				ProgressToken mcp.ProgressToken `json:"progressToken,omitempty"`
-			} `json:"_meta,omitempty"`
-		}{
+		Params: mcp.CallToolParams{
 			Arguments: map[string]any{
 				"name":      "test-pod",
 				"namespace": "default",
@@ -104,7 +104,7 @@ func TestParseAndValidateLogsParams(t *testing.T) {
 		t.Run(tc.name, func(t *testing.T) {
 			client := &FakeLogClient{}
 			multiClient := NewFakeMultiClusterClient(client)
-	tool := NewLogTool(multiClient)
+	tool := NewLogTool(multiClient, DefaultOptions())
 			result, err := tool.parseAndValidateLogsParams(tc.args)
 
 			if tc.expectedErr {
@@ -118,6 +118,19 @@ func TestParseAndValidateLogsParams(t *testing.T) {
 	}
 }
 
+func TestParseAndValidateLogsParams_ConfiguredDefaults(t *testing.T) {
+	opts := DefaultOptions()
+	opts.DefaultNamespace = "team-a"
+	opts.DefaultLogTailLines = 20
+	tool := NewLogTool(NewFakeMultiClusterClient(&FakeLogClient{}), opts)
+
+	result, err := tool.parseAndValidateLogsParams(map[string]any{"name": "test-pod"})
+
+	assert.NoError(t, err)
+	assert.Equal(t, "team-a", result.Namespace)
+	assert.Equal(t, int64(20), result.Tail)
+}
+
 func TestSinceSeconds(t *testing.T) {
 	testCases := []struct {
 		name     string
@@ -192,10 +205,35 @@ func TestSinceTime(t *testing.T) {
 func TestLogTool_Tool(t *testing.T) {
 	client := &FakeLogClient{}
 	multiClient := NewFakeMultiClusterClient(client)
-	tool := NewLogTool(multiClient)
+	tool := NewLogTool(multiClient, DefaultOptions())
 
 	mcpTool := tool.Tool()
 
 	assert.Equal(t, "get_pod_logs", mcpTool.Name)
 	assert.Contains(t, mcpTool.Description, "Get logs from a Kubernetes pod")
 }
+
+func TestLogTool_Handler_NamespacePolicy(t *testing.T) {
+	namespaces, err := policy.NewNamespacePolicy([]policy.NamespaceRule{{Allowed: []string{"app-*"}}})
+	assert.NoError(t, err)
+	opts := DefaultOptions()
+	opts.Namespaces = namespaces
+
+	client := &FakeLogClient{err: errors.New("clientset error")}
+	tool := NewLogTool(NewFakeMultiClusterClient(client), opts)
+
+	req := mcp.CallToolRequest{
+		Params: mcp.CallToolParams{
+			Arguments: map[string]any{
+				"name": "test-pod",
+			},
+		},
+	}
+
+	// The default namespace is not allowed, so the request is rejected before any API call
+	actualResult, actualErr := tool.Handler(context.Background(), req)
+
+	assert.Error(t, actualErr)
+	assert.Contains(t, actualErr.Error(), "namespace 'default' is blocked in context 'test-context' by the server's namespace policy: does not match any allowed pattern")
+	assert.Nil(t, actualResult)
+}
@@ -1,12 +1,19 @@
 package tools
 
 import (
+	"context"
 	"fmt"
+	"strconv"
 
 	openapi_v2 "github.com/google/gnostic-models/openapiv2"
 	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/apis/meta/v1/unstructured"
+	"k8s.io/apimachinery/pkg/runtime"
+	"k8s.io/apimachinery/pkg/runtime/schema"
 	"k8s.io/apimachinery/pkg/version"
 	"k8s.io/client-go/discovery"
+	"k8s.io/client-go/dynamic"
+	"k8s.io/client-go/dynamic/fake"
 	"k8s.io/client-go/openapi"
 	restclient "k8s.io/client-go/rest"
 )
@@ -85,7 +92,7 @@ func NewFakeMultiClusterClient(client Client) *FakeMultiClusterClient {
 	return &FakeMultiClusterClient{client: client}
 }
 
-func (f *FakeMultiClusterClient) GetClient(context string) (Client, error) {
+func (f *FakeMultiClusterClient) GetClient(ctx context.Context, contextName string) (Client, error) {
 	return f.client, nil
 }
 
@@ -93,9 +100,94 @@ func (f *FakeMultiClusterClient) GetDefaultContext() string {
 	return "test-context"
 }
 
-func (f *FakeMultiClusterClient) ListContexts() ([]string, error) {
-	return []string{"test-context", "other-context"}, nil
+func (f *FakeMultiClusterClient) ListContexts() ([]ContextInfo, error) {
+	return []ContextInfo{{Name: "test-context"}, {Name: "other-context"}}, nil
 }
 
 // Compile-time verification that FakeMultiClusterClient implements MultiClusterClientInterface
 var _ MultiClusterClientInterface = (*FakeMultiClusterClient)(nil)
+
+// FakeObjectsClient serves the given objects from a fake dynamic client and the given resources from discovery
+type FakeObjectsClient struct {
+	FakeKubernetesClient
+	resources []*metav1.APIResourceList
+	objects   []runtime.Object
+}
+
+func (f FakeObjectsClient) DiscoClient() (discovery.DiscoveryInterface, error) {
+	return &fakeDiscoveryClient{apiResourceLists: f.resources}, nil
+}
+
+func (f FakeObjectsClient) ResourceInterface(gvr schema.GroupVersionResource, namespaced bool, ns string) (dynamic.ResourceInterface, error) {
+	// The fake client sets the type of the objects it is given, so give each client its own copies
+	objects := make([]runtime.Object, 0, len(f.objects))
+	for _, obj := range f.objects {
+		objects = append(objects, obj.DeepCopyObject())
+	}
+	fakeDynClient := fake.NewSimpleDynamicClient(runtime.NewScheme(), objects...)
+	if !namespaced {
+		return fakeDynClient.Resource(gvr), nil
+	}
+	return fakeDynClient.Resource(gvr).Namespace(ns), nil
+}
+
+// PagedObjectsClient is a FakeObjectsClient whose lists honor limit and continue like the API server,
+// using the offset of the next item as continue token
+type PagedObjectsClient struct {
+	FakeObjectsClient
+}
+
+func (p PagedObjectsClient) ResourceInterface(gvr schema.GroupVersionResource, namespaced bool, ns string) (dynamic.ResourceInterface, error) {
+	ri, err := p.FakeObjectsClient.ResourceInterface(gvr, namespaced, ns)
+	if err != nil {
+		return nil, err
+	}
+	return pagedResourceInterface{ResourceInterface: ri}, nil
+}
+
+type pagedResourceInterface struct {
+	dynamic.ResourceInterface
+}
+
+func (p pagedResourceInterface) List(ctx context.Context, opts metav1.ListOptions) (*unstructured.UnstructuredList, error) {
+	list, err := p.ResourceInterface.List(ctx, metav1.ListOptions{LabelSelector: opts.LabelSelector, FieldSelector: opts.FieldSelector})
+	if err != nil {
+		return nil, err
+	}
+	offset := 0
+	if opts.Continue != "" {
+		if offset, err = strconv.Atoi(opts.Continue); err != nil {
+			return nil, fmt.Errorf("invalid continue token %q", opts.Continue)
+		}
+	}
+	items := list.Items[min(offset, len(list.Items)):]
+	if opts.Limit > 0 && int64(len(items)) > opts.Limit {
+		remaining := int64(len(items)) - opts.Limit
+		items = items[:opts.Limit]
+		list.SetContinue(strconv.Itoa(offset + len(items)))
+		list.SetRemainingItemCount(&remaining)
+	}
+	list.Items = items
+	return list, nil
+}
+
+// newUnstructured returns a minimal object for FakeObjectsClient
+func newUnstructured(apiVersion, kind, namespace, name string) *unstructured.Unstructured {
+	obj := &unstructured.Unstructured{}
+	obj.SetAPIVersion(apiVersion)
+	obj.SetKind(kind)
+	obj.SetNamespace(namespace)
+	obj.SetName(name)
+	return obj
+}
+
+// namespacedTestResources is the discovery data served by FakeObjectsClient in most tests
+var namespacedTestResources = []*metav1.APIResourceList{
+	{
+		GroupVersion: "v1",
+		APIResources: []metav1.APIResource{
+			{Kind: "Pod", Name: "pods", Namespaced: true, ShortNames: []string{"po"}},
+			{Kind: "Namespace", Name: "namespaces", Namespaced: false, ShortNames: []string{"ns"}},
+		},
+	},
+}
@@ -0,0 +1,169 @@
+package tools
+
+import (
+	"context"
+	"fmt"
+	"strings"
+	"sync"
+
+	"github.com/mark3labs/mcp-go/mcp"
+	"k8s.io/apimachinery/pkg/runtime/schema"
+)
+
+// maxConcurrentLists is the number of kinds listed at the same time by a multi-kind request.
+const maxConcurrentLists = 8
+
+// MultiKindResult is the result of list_resources for several kinds, in the order they were
+// requested or discovered.
+type MultiKindResult struct {
+	Kinds []KindListResult `json:"kinds"`
+}
+
+// KindListResult holds the resources of one kind, or the error listing them. Listing one kind
+// failing, for example because it is blocked by policy, does not fail the others.
+type KindListResult struct {
+	Kind     string `json:"kind"`
+	Resource string `json:"resource"`
+	Error    string `json:"error,omitempty"`
+	*ListResourcesResult
+	Summary *ListSummary `json:"summary,omitempty"`
+
+	// value is the result as returned by ListTool.list, used for table and name output
+	value any
+}
+
+// tables prints a table per kind, titled with its resource name.
+func (r MultiKindResult) tables() []textTable {
+	tables := make([]textTable, 0, len(r.Kinds))
+	for _, kind := range r.Kinds {
+		var table textTable
+		if t, ok := kind.value.(tabular); ok {
+			table = t.table()
+		}
+		if kind.Error != "" {
+			table.footer = append(table.footer, "error: "+kind.Error)
+		}
+		table.title = kind.Resource
+		tables = append(tables, table)
+	}
+	return tables
+}
+
+func (r MultiKindResult) names() []string {
+	var names []string
+	for _, kind := range r.Kinds {
+		if n, ok := kind.value.(named); ok {
+			names = append(names, n.names()...)
+		}
+	}
+	return names
+}
+
+// splitKinds splits a comma-separated list of kinds, like 'kubectl get deploy,sts'.
+func splitKinds(kind string) []string {
+	var kinds []string
+	for _, k := range strings.Split(kind, ",") {
+		if k = strings.TrimSpace(k); k != "" {
+			kinds = append(kinds, k)
+		}
+	}
+	return kinds
+}
+
+// listKinds lists several kinds concurrently. All kinds are resolved against one snapshot of
+// the discovery data, and a kind given twice, such as "po,pods", is listed once.
+func (l ListTool) listKinds(ctx context.Context, client Client, input *ListResourcesInput) (*mcp.CallToolResult, error) {
+	matches, err := resolveKinds(client, input.Kinds)
+	if err != nil {
+		return nil, err
+	}
+
+	var unique []*gvrMatch
+	seen := map[schema.GroupVersionResource]bool{}
+	for _, match := range matches {
+		gvr := *match.ToGroupVersionResource()
+		if !seen[gvr] {
+			seen[gvr] = true
+			unique = append(unique, match)
+		}
+	}
+
+	result, redactions := l.listMatches(ctx, client, unique, input)
+	return l.opts.formatOutput(result, input.Output, redactions)
+}
+
+// listGroup lists the objects of every resource type in the API groups matching groupFilter,
+// like 'kubectl get' with all the kinds of a project.
+func (l ListTool) listGroup(ctx context.Context, client Client, input *ListResourcesInput) (*mcp.CallToolResult, error) {
+	apiResourceLists, err := serverPreferredResources(client)
+	if err != nil {
+		return nil, err
+	}
+	matches, err := findGVRsByGroupSubstring(apiResourceLists, input.GroupFilter)
+	if err != nil {
+		return nil, fmt.Errorf("failed to find resources by group substring: %w", err)
+	}
+
+	result, redactions := l.listMatches(ctx, client, matches, input)
+	return l.opts.formatOutput(result, input.Output, redactions)
+}
+
+// listMatches lists each match with at most maxConcurrentLists lists running at a time. The
+// results keep the order of matches, and it returns the total number of redacted values.
+func (l ListTool) listMatches(ctx context.Context, client Client, matches []*gvrMatch, input *ListResourcesInput) (MultiKindResult, int) {
+	results := make([]KindListResult, len(matches))
+	redactions := make([]int, len(matches))
+	runWorkers(len(matches), maxConcurrentLists, func(i int) {
+		results[i], redactions[i] = l.listKind(ctx, client, matches[i], input)
+	})
+
+	total := 0
+	for _, count := range redactions {
+		total += count
+	}
+	return MultiKindResult{Kinds: results}, total
+}
+
+// runWorkers calls fn for every index from 0 to n-1 on a pool of at most workers goroutines and
+// waits for all calls to return.
+func runWorkers(n, workers int, fn func(i int)) {
+	indexes := make(chan int)
+	var wg sync.WaitGroup
+	for range min(n, workers) {
+		wg.Add(1)
+		go func() {
+			defer wg.Done()
+			for i := range indexes {
+				fn(i)
+			}
+		}()
+	}
+	for i := range n {
+		indexes <- i
+	}
+	close(indexes)
+	wg.Wait()
+}
+
+// listKind lists one kind of a multi-kind request, recording an error in the result instead of
+// returning it.
+func (l ListTool) listKind(ctx context.Context, client Client, match *gvrMatch, input *ListResourcesInput) (KindListResult, int) {
+	result := KindListResult{
+		Kind:     match.apiRes.Kind,
+		Resource: gvrString(*match.ToGroupVersionResource()),
+	}
+	value, redactions, err := l.list(ctx, client, match, input)
+	if err != nil {
+		result.Error = err.Error()
+		return result, 0
+	}
+
+	result.value = value
+	switch v := value.(type) {
+	case ListResourcesResult:
+		result.ListResourcesResult = &v
+	case ListSummary:
+		result.Summary = &v
+	}
+	return result, redactions
+}
@@ -0,0 +1,280 @@
+package tools
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"strings"
+	"sync/atomic"
+	"testing"
+	"time"
+
+	"github.com/mark3labs/mcp-go/mcp"
+	"github.com/stretchr/testify/assert"
+	apierrors "k8s.io/apimachinery/pkg/api/errors"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/apis/meta/v1/unstructured"
+	"k8s.io/apimachinery/pkg/runtime"
+	"k8s.io/apimachinery/pkg/runtime/schema"
+	"k8s.io/client-go/dynamic"
+)
+
+// workloadTestResources is discovery data with workloads, services and secrets
+var workloadTestResources = []*metav1.APIResourceList{
+	{
+		GroupVersion: "v1",
+		APIResources: []metav1.APIResource{
+			{Kind: "Pod", Name: "pods", Namespaced: true, ShortNames: []string{"po"}},
+			{Kind: "Service", Name: "services", Namespaced: true, ShortNames: []string{"svc"}},
+			{Kind: "Secret", Name: "secrets", Namespaced: true},
+		},
+	},
+	{
+		GroupVersion: "apps/v1",
+		APIResources: []metav1.APIResource{
+			{Kind: "Deployment", Name: "deployments", Namespaced: true, ShortNames: []string{"deploy"}},
+		},
+	},
+}
+
+func TestListTool_MultipleKinds(t *testing.T) {
+	client := FakeObjectsClient{
+		resources: workloadTestResources,
+		objects: []runtime.Object{
+			newUnstructured("apps/v1", "Deployment", "apps", "web"),
+			newUnstructured("v1", "Pod", "apps", "web-1"),
+			newUnstructured("v1", "Pod", "apps", "web-2"),
+			newUnstructured("v1", "Service", "apps", "web"),
+		},
+	}
+	l := NewListTool(NewFakeMultiClusterClient(client), DefaultOptions())
+
+	result, err := l.Handler(context.Background(), mcp.CallToolRequest{Params: mcp.CallToolParams{Arguments: map[string]any{
+		"kind":      "deploy, po,svc,pods,secrets",
+		"namespace": "apps",
+		"fields":    []any{".metadata.name"},
+	}}})
+	assert.NoError(t, err)
+	assert.Equal(t, `{"kinds":[`+
+		`{"kind":"Deployment","resource":"deployments.apps","items":[{".metadata.name":"web","name":"web","namespace":"apps"}]},`+
+		`{"kind":"Pod","resource":"pods","items":[{".metadata.name":"web-1","name":"web-1","namespace":"apps"},{".metadata.name":"web-2","name":"web-2","namespace":"apps"}]},`+
+		`{"kind":"Service","resource":"services","items":[{".metadata.name":"web","name":"web","namespace":"apps"}]},`+
+		`{"kind":"Secret","resource":"secrets","error":"resource 'secrets' is blocked by the server's resource policy"}]}`,
+		result.Content[0].(mcp.TextContent).Text)
+
+	result, err = l.Handler(context.Background(), mcp.CallToolRequest{Params: mcp.CallToolParams{Arguments: map[string]any{
+		"kind":   "deploy,po",
+		"output": "name",
+	}}})
+	assert.NoError(t, err)
+	assert.Equal(t, "deployment.apps/web\npod/web-1\npod/web-2\n", result.Content[0].(mcp.TextContent).Text)
+
+	result, err = l.Handler(context.Background(), mcp.CallToolRequest{Params: mcp.CallToolParams{Arguments: map[string]any{
+		"kind":    "deploy,po",
+		"summary": true,
+		"groupBy": ".status.phase",
+	}}})
+	assert.NoError(t, err)
+	assert.Contains(t, result.Content[0].(mcp.TextContent).Text,
+		`{"kind":"Pod","resource":"pods","summary":{"kind":"Pod","groupBy":".status.phase","total":2,"namespaces":[{"namespace":"apps","total":2,"groups":{"<none>":2}}]}}`)
+
+	// An unknown kind fails the whole request, like kubectl
+	_, err = l.Handler(context.Background(), mcp.CallToolRequest{Params: mcp.CallToolParams{Arguments: map[string]any{
+		"kind": "deploy,widgets",
+	}}})
+	assert.Error(t, err)
+}
+
+func TestListTool_MultipleKindsTable(t *testing.T) {
+	client := &TableObjectsClient{
+		FakeObjectsClient: FakeObjectsClient{resources: workloadTestResources},
+		response: `{"kind": "Table", "apiVersion": "meta.k8s.io/v1",
+  "columnDefinitions": [{"name": "Name", "type": "string", "format": "name", "priority": 0}],
+  "rows": [{"cells": ["web"], "object": {"metadata": {"name": "web", "namespace": "apps"}}}]}`,
+	}
+	l := NewListTool(NewFakeMultiClusterClient(client), DefaultOptions())
+
+	result, err := l.Handler(context.Background(), mcp.CallToolRequest{Params: mcp.CallToolParams{Arguments: map[string]any{
+		"kind":      "deploy,secrets",
+		"namespace": "apps",
+		"output":    "table",
+	}}})
+	assert.NoError(t, err)
+	assert.Equal(t, `# deployments.apps
+NAME
+web
+
+# secrets
+error: resource 'secrets' is blocked by the server's resource policy
+`, result.Content[0].(mcp.TextContent).Text)
+}
+
+func TestParseAndValidateListParams_MultipleKinds(t *testing.T) {
+	input, err := parseAndValidateListParams(map[string]any{"kind": "deploy,sts,po,svc"}, DefaultOptions())
+	assert.NoError(t, err)
+	assert.Equal(t, []string{"deploy", "sts", "po", "svc"}, input.Kinds)
+
+	for _, args := range []map[string]any{
+		{"kind": ",", "namespace": "default"},
+		{"kind": "deploy,in valid"},
+		{"kind": "deploy,po", "continue": "token"},
+		{"kind": "deploy,po", "groupFilter": "apps"},
+		{"kind": "all", "instances": true},
+		{"kind": "Kustomization", "groupFilter": "flux", "instances": true},
+		{"kind": "all", "groupFilter": "flux", "instances": true, "continue": "token"},
+	} {
+		_, err := parseAndValidateListParams(args, DefaultOptions())
+		assert.Error(t, err, args)
+	}
+}
+
+// fluxTestResources is discovery data for FluxCD with a subresource and a resource that cannot be listed
+var fluxTestResources = []*metav1.APIResourceList{
+	{
+		GroupVersion: "kustomize.toolkit.fluxcd.io/v1",
+		APIResources: []metav1.APIResource{
+			{Kind: "Kustomization", Name: "kustomizations", Namespaced: true, ShortNames: []string{"ks"}, Verbs: []string{"get", "list", "watch"}, Categories: []string{"fluxcd"}},
+			{Kind: "Kustomization", Name: "kustomizations/status", Namespaced: true, Verbs: []string{"get", "patch"}},
+		},
+	},
+	{
+		GroupVersion: "source.toolkit.fluxcd.io/v1",
+		APIResources: []metav1.APIResource{
+			{Kind: "GitRepository", Name: "gitrepositories", Namespaced: true, Verbs: []string{"get", "list"}},
+			{Kind: "Bucket", Name: "buckets", Namespaced: true, Verbs: []string{"get", "list"}},
+			{Kind: "ArtifactReview", Name: "artifactreviews", Namespaced: true, Verbs: []string{"create"}},
+		},
+	},
+	{
+		GroupVersion: "apps/v1",
+		APIResources: []metav1.APIResource{
+			{Kind: "Deployment", Name: "deployments", Namespaced: true, Verbs: []string{"get", "list"}},
+		},
+	},
+}
+
+// ForbiddenObjectsClient is a FakeObjectsClient that forbids listing the given resources
+type ForbiddenObjectsClient struct {
+	FakeObjectsClient
+	forbidden string
+}
+
+func (f ForbiddenObjectsClient) ResourceInterface(gvr schema.GroupVersionResource, namespaced bool, ns string) (dynamic.ResourceInterface, error) {
+	if gvr.Resource == f.forbidden {
+		return forbiddenResourceInterface{gvr: gvr}, nil
+	}
+	return f.FakeObjectsClient.ResourceInterface(gvr, namespaced, ns)
+}
+
+type forbiddenResourceInterface struct {
+	dynamic.ResourceInterface
+	gvr schema.GroupVersionResource
+}
+
+func (f forbiddenResourceInterface) List(ctx context.Context, opts metav1.ListOptions) (*unstructured.UnstructuredList, error) {
+	return nil, apierrors.NewForbidden(f.gvr.GroupResource(), "", errors.New("access denied"))
+}
+
+func TestListTool_GroupInstances(t *testing.T) {
+	client := ForbiddenObjectsClient{
+		FakeObjectsClient: FakeObjectsClient{
+			resources: fluxTestResources,
+			objects: []runtime.Object{
+				newUnstructured("kustomize.toolkit.fluxcd.io/v1", "Kustomization", "flux-system", "apps"),
+				newUnstructured("kustomize.toolkit.fluxcd.io/v1", "Kustomization", "flux-system", "infra"),
+				newUnstructured("source.toolkit.fluxcd.io/v1", "GitRepository", "flux-system", "flux-system"),
+				newUnstructured("apps/v1", "Deployment", "flux-system", "source-controller"),
+			},
+		},
+		forbidden: "buckets",
+	}
+	l := NewListTool(NewFakeMultiClusterClient(client), DefaultOptions())
+
+	result, err := l.Handler(context.Background(), mcp.CallToolRequest{Params: mcp.CallToolParams{Arguments: map[string]any{
+		"kind":        "all",
+		"groupFilter": "fluxcd",
+		"instances":   true,
+		"output":      "name",
+	}}})
+	assert.NoError(t, err)
+	assert.Equal(t, "kustomization.kustomize.toolkit.fluxcd.io/apps\nkustomization.kustomize.toolkit.fluxcd.io/infra\ngitrepository.source.toolkit.fluxcd.io/flux-system\n",
+		result.Content[0].(mcp.TextContent).Text)
+
+	result, err = l.Handler(context.Background(), mcp.CallToolRequest{Params: mcp.CallToolParams{Arguments: map[string]any{
+		"groupFilter": "fluxcd",
+		"instances":   true,
+		"fields":      []any{".metadata.name"},
+	}}})
+	assert.NoError(t, err)
+	assert.Equal(t, `{"kinds":[`+
+		`{"kind":"Kustomization","resource":"kustomizations.kustomize.toolkit.fluxcd.io","items":[{".metadata.name":"apps","name":"apps","namespace":"flux-system"},{".metadata.name":"infra","name":"infra","namespace":"flux-system"}]},`+
+		`{"kind":"GitRepository","resource":"gitrepositories.source.toolkit.fluxcd.io","items":[{".metadata.name":"flux-system","name":"flux-system","namespace":"flux-system"}]},`+
+		`{"kind":"Bucket","resource":"buckets.source.toolkit.fluxcd.io","error":"failed to list resources: buckets.source.toolkit.fluxcd.io is forbidden: access denied"}]}`,
+		result.Content[0].(mcp.TextContent).Text)
+
+	result, err = l.Handler(context.Background(), mcp.CallToolRequest{Params: mcp.CallToolParams{Arguments: map[string]any{
+		"kind":        "all",
+		"groupFilter": "argoproj",
+		"instances":   true,
+	}}})
+	assert.NoError(t, err)
+	assert.Equal(t, `{"kinds":[]}`, result.Content[0].(mcp.TextContent).Text)
+}
+
+// ConcurrencyObjectsClient is a FakeObjectsClient that records the most lists running at once
+type ConcurrencyObjectsClient struct {
+	FakeObjectsClient
+	running *atomic.Int32
+	peak    *atomic.Int32
+}
+
+func (c ConcurrencyObjectsClient) ResourceInterface(gvr schema.GroupVersionResource, namespaced bool, ns string) (dynamic.ResourceInterface, error) {
+	ri, err := c.FakeObjectsClient.ResourceInterface(gvr, namespaced, ns)
+	if err != nil {
+		return nil, err
+	}
+	return concurrencyResourceInterface{ResourceInterface: ri, client: c}, nil
+}
+
+type concurrencyResourceInterface struct {
+	dynamic.ResourceInterface
+	client ConcurrencyObjectsClient
+}
+
+func (c concurrencyResourceInterface) List(ctx context.Context, opts metav1.ListOptions) (*unstructured.UnstructuredList, error) {
+	running := c.client.running.Add(1)
+	defer c.client.running.Add(-1)
+	for peak := c.client.peak.Load(); running > peak && !c.client.peak.CompareAndSwap(peak, running); peak = c.client.peak.Load() {
+	}
+	time.Sleep(10 * time.Millisecond)
+	return c.ResourceInterface.List(ctx, opts)
+}
+
+func TestListTool_ListMatchesBounded(t *testing.T) {
+	var objects []runtime.Object
+	var matches []*gvrMatch
+	for i := range 3 * maxConcurrentLists {
+		kind := fmt.Sprintf("Widget%d", i)
+		objects = append(objects, newUnstructured("example.com/v1", kind, "default", "w"))
+		apiRes := metav1.APIResource{Kind: kind, Name: strings.ToLower(kind) + "s", Namespaced: true, Verbs: []string{"list"}}
+		matches = append(matches, newGvrMatch(&apiRes, "example.com/v1", true))
+	}
+	client := ConcurrencyObjectsClient{
+		FakeObjectsClient: FakeObjectsClient{objects: objects},
+		running:           &atomic.Int32{},
+		peak:              &atomic.Int32{},
+	}
+	l := NewListTool(NewFakeMultiClusterClient(client), DefaultOptions())
+
+	input, err := parseAndValidateListParams(map[string]any{"kind": "all", "groupFilter": "example", "instances": true, "output": "name"}, DefaultOptions())
+	assert.NoError(t, err)
+	result, _ := l.listMatches(context.Background(), client, matches, input)
+	assert.Len(t, result.Kinds, len(matches))
+	for i, kind := range result.Kinds {
+		assert.Equal(t, matches[i].apiRes.Kind, kind.Kind)
+		assert.Empty(t, kind.Error)
+		assert.Equal(t, []string{strings.ToLower(kind.Kind) + ".example.com/w"}, kind.names())
+	}
+	assert.LessOrEqual(t, client.peak.Load(), int32(maxConcurrentLists))
+	assert.Greater(t, client.peak.Load(), int32(1))
+}
@@ -0,0 +1,67 @@
+package tools
+
+import (
+	"fmt"
+
+	"github.com/kkb0318/kubernetes-mcp/src/policy"
+	"k8s.io/apimachinery/pkg/apis/meta/v1/unstructured"
+)
+
+// namespaceAccess applies the namespace policy of the context a request targets.
+type namespaceAccess struct {
+	context string
+	filter  *policy.Filter
+}
+
+// namespaceAccess resolves the namespace rules for the requested context, falling back to the
+// default context when none is given.
+func (o Options) namespaceAccess(multiClient MultiClusterClientInterface, contextName string) namespaceAccess {
+	if contextName == "" {
+		contextName = multiClient.GetDefaultContext()
+	}
+	return namespaceAccess{context: contextName, filter: o.Namespaces.ForContext(contextName)}
+}
+
+// check returns an error if requests to the namespace are blocked. The empty namespace
+// (all namespaces or cluster-scoped) is always accepted; callers filter the results instead.
+func (a namespaceAccess) check(namespace string) error {
+	if namespace == "" {
+		return nil
+	}
+	if err := a.filter.Check(namespace); err != nil {
+		return fmt.Errorf("namespace '%s' is blocked in context '%s' by the server's namespace policy: %w", namespace, a.context, err)
+	}
+	return nil
+}
+
+// allowed reports whether objects in the namespace may be returned.
+// Cluster-scoped objects have no namespace and are always allowed.
+func (a namespaceAccess) allowed(namespace string) bool {
+	return namespace == "" || a.filter.Allowed(namespace)
+}
+
+// filtersList reports whether listing resources may drop items in blocked namespaces, which is
+// the case for namespace objects and for namespaced resources listed across all namespaces.
+// The remaining item count reported by the server includes those items and cannot be trusted then.
+func (a namespaceAccess) filtersList(namespaced bool, namespace string, isNamespaceResource bool) bool {
+	return !a.filter.IsZero() && (isNamespaceResource || namespaced && namespace == "")
+}
+
+// filterList drops items in blocked namespaces from the list. Namespace objects themselves
+// are filtered by name so blocked namespaces are not revealed either.
+func (a namespaceAccess) filterList(list *unstructured.UnstructuredList, isNamespaceResource bool) {
+	if a.filter.IsZero() || list == nil {
+		return
+	}
+	items := list.Items[:0]
+	for _, item := range list.Items {
+		namespace := item.GetNamespace()
+		if isNamespaceResource {
+			namespace = item.GetName()
+		}
+		if a.allowed(namespace) {
+			items = append(items, item)
+		}
+	}
+	list.Items = items
+}
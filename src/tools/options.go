@@ -0,0 +1,61 @@
+package tools
+
+import (
+	"fmt"
+
+	"github.com/kkb0318/kubernetes-mcp/src/policy"
+	"github.com/kkb0318/kubernetes-mcp/src/prune"
+	"github.com/kkb0318/kubernetes-mcp/src/redact"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+)
+
+// Options holds server-wide settings shared by all tools.
+type Options struct {
+	// DefaultTimeoutSeconds is used for list operations when a request does not set timeoutSeconds.
+	DefaultTimeoutSeconds int64
+	// DefaultEventsLimit is the number of events returned when a request does not set limit (0 means no limit).
+	DefaultEventsLimit int64
+	// DefaultLogTailLines is the number of log lines returned when a request does not set tail (0 means all lines).
+	DefaultLogTailLines int64
+	// DefaultNamespace is used by tools that need a namespace when a request does not set one.
+	DefaultNamespace string
+	// Namespaces restricts the namespaces tools may read, per context. Nil allows every namespace.
+	Namespaces *policy.NamespacePolicy
+	// Resources blocks API resources, or limits them to their metadata. Nil allows every resource.
+	Resources *policy.ResourcePolicy
+	// Redactor removes sensitive values from every tool's output. Nil disables redaction.
+	Redactor *redact.Redactor
+	// Pruner removes noise, such as managedFields, from the objects returned by list_resources and
+	// describe_resource. Nil returns objects unchanged.
+	Pruner *prune.Pruner
+	// EnabledTools restricts registration to the named tools. Empty means all tools.
+	EnabledTools []string
+	// DisabledTools lists tools that are never registered.
+	DisabledTools []string
+}
+
+// DefaultOptions returns the settings used when nothing is configured.
+// Secrets are blocked, and the default redaction rules and pruning paths applied.
+func DefaultOptions() Options {
+	resources, err := policy.NewResourcePolicy(policy.DefaultDeniedResources, nil)
+	if err != nil {
+		panic(fmt.Sprintf("invalid default resource policy: %v", err))
+	}
+	redactor, err := redact.New(redact.DefaultRules())
+	if err != nil {
+		panic(fmt.Sprintf("invalid default redaction rules: %v", err))
+	}
+	pruner, err := prune.New(prune.DefaultPaths())
+	if err != nil {
+		panic(fmt.Sprintf("invalid default pruning paths: %v", err))
+	}
+	return Options{
+		DefaultTimeoutSeconds: 30,
+		DefaultEventsLimit:    100,
+		DefaultLogTailLines:   100,
+		DefaultNamespace:      metav1.NamespaceDefault,
+		Resources:             resources,
+		Redactor:              redactor,
+		Pruner:                pruner,
+	}
+}
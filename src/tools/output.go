@@ -0,0 +1,144 @@
+package tools
+
+import (
+	"bytes"
+	"encoding/json"
+	"fmt"
+	"strings"
+
+	"github.com/mark3labs/mcp-go/mcp"
+	"k8s.io/apimachinery/pkg/runtime/schema"
+	"sigs.k8s.io/yaml"
+)
+
+// Output formats selected with the "output" argument.
+const (
+	outputJSON  = "json"
+	outputYAML  = "yaml"
+	outputTable = "table"
+	outputName  = "name"
+)
+
+// outputFormats lists the accepted values of the "output" argument.
+var outputFormats = []string{outputJSON, outputYAML, outputTable, outputName}
+
+// withOutput adds the "output" argument shared by the tools returning Kubernetes objects.
+func withOutput() mcp.ToolOption {
+	return mcp.WithString("output",
+		mcp.Description("Output format: 'json' (default), 'yaml', 'table' for the columns 'kubectl get' shows, or 'name' for one kind/name per line"),
+		mcp.Enum(outputFormats...),
+	)
+}
+
+// withRaw adds the "raw" argument, which turns off the pruning of noisy fields such as managedFields.
+func withRaw() mcp.ToolOption {
+	return mcp.WithBoolean("raw",
+		mcp.Description("Return objects as stored, without removing managedFields, the last-applied-configuration annotation and other noisy fields (default: false)"),
+	)
+}
+
+// parseOutput reads the "output" argument. An empty value selects JSON.
+func parseOutput(args map[string]any) (string, error) {
+	output, _ := args["output"].(string)
+	if output == "" {
+		return "", nil
+	}
+	for _, format := range outputFormats {
+		if output == format {
+			return output, nil
+		}
+	}
+	return "", fmt.Errorf("invalid output %q: must be one of %s", output, strings.Join(outputFormats, ", "))
+}
+
+// tabular is implemented by tool results that can be printed with output=table.
+type tabular interface {
+	table() textTable
+}
+
+// multiTabular is implemented by tool results printed as several tables with output=table.
+type multiTabular interface {
+	tables() []textTable
+}
+
+// named is implemented by tool results that can be printed with output=name.
+type named interface {
+	names() []string
+}
+
+// namedValue attaches the kind/name of the objects in a JSON value for output=name.
+type namedValue struct {
+	value       any
+	objectNames []string
+}
+
+func (n namedValue) MarshalJSON() ([]byte, error) {
+	return json.Marshal(n.value)
+}
+
+func (n namedValue) names() []string {
+	return n.objectNames
+}
+
+// objectName returns the name of an object as 'kubectl get -o name' prints it, e.g.
+// "pod/web" or "deployment.apps/web".
+func objectName(kind string, gvr schema.GroupVersionResource, name string) string {
+	kind = strings.ToLower(kind)
+	if gvr.Group != "" {
+		kind += "." + gvr.Group
+	}
+	return kind + "/" + name
+}
+
+// formatOutput renders a tool's result in the requested output format and returns it through
+// toolResult, so every format is redacted the same way. JSON and YAML marshal value; table requires
+// value to implement tabular or multiTabular, and name to implement named. redactions counts values already redacted from value.
+func (o Options) formatOutput(value any, output string, redactions int) (*mcp.CallToolResult, error) {
+	switch output {
+	case outputTable:
+		switch t := value.(type) {
+		case tabular:
+			return o.redactedToolResult(t.table().render(), redactions)
+		case multiTabular:
+			var out [][]byte
+			for _, table := range t.tables() {
+				out = append(out, table.render())
+			}
+			return o.redactedToolResult(bytes.Join(out, []byte("\n")), redactions)
+		}
+		return nil, fmt.Errorf("output '%s' is not supported for this request", output)
+	case outputName:
+		n, ok := value.(named)
+		if !ok {
+			return nil, fmt.Errorf("output '%s' is not supported for this request", output)
+		}
+		var out strings.Builder
+		for _, name := range n.names() {
+			out.WriteString(name + "\n")
+		}
+		return o.redactedToolResult([]byte(out.String()), redactions)
+	}
+
+	// Keep characters such as "<" and "&" readable, like the redactor does
+	var buf bytes.Buffer
+	encoder := json.NewEncoder(&buf)
+	encoder.SetEscapeHTML(false)
+	if err := encoder.Encode(value); err != nil {
+		return nil, fmt.Errorf("failed to marshal output: %w", err)
+	}
+	out := bytes.TrimSuffix(buf.Bytes(), []byte("\n"))
+	if output != outputYAML {
+		return o.redactedToolResult(out, redactions)
+	}
+
+	// Redact while the output is still JSON, so key and path rules apply
+	out, count, err := o.Redactor.JSON(out)
+	if err != nil {
+		return nil, err
+	}
+	yamlOut, err := yaml.JSONToYAML(out)
+	if err != nil {
+		return nil, fmt.Errorf("failed to convert output to YAML: %w", err)
+	}
+	return newToolResult(yamlOut, redactions+count), nil
+}
@@ -0,0 +1,172 @@
+package tools
+
+import (
+	"context"
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/mark3labs/mcp-go/mcp"
+	"github.com/stretchr/testify/assert"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/apis/meta/v1/unstructured"
+	"k8s.io/apimachinery/pkg/runtime"
+)
+
+func TestParseOutput(t *testing.T) {
+	testCases := []struct {
+		name        string
+		args        map[string]any
+		expected    string
+		expectedErr bool
+	}{
+		{name: "Default", args: map[string]any{}, expected: ""},
+		{name: "YAML", args: map[string]any{"output": "yaml"}, expected: outputYAML},
+		{name: "Name", args: map[string]any{"output": "name"}, expected: outputName},
+		{name: "Invalid", args: map[string]any{"output": "wide"}, expectedErr: true},
+	}
+
+	for _, tc := range testCases {
+		t.Run(tc.name, func(t *testing.T) {
+			actual, err := parseOutput(tc.args)
+			if tc.expectedErr {
+				assert.Error(t, err)
+				return
+			}
+			assert.NoError(t, err)
+			assert.Equal(t, tc.expected, actual)
+		})
+	}
+}
+
+func TestFormatOutput_NotSupported(t *testing.T) {
+	opts := DefaultOptions()
+	for _, output := range []string{outputTable, outputName} {
+		_, err := opts.formatOutput(map[string]any{"name": "web"}, output, 0)
+		assert.Error(t, err)
+		assert.Contains(t, err.Error(), "is not supported for this request")
+	}
+}
+
+func TestOutput_Tools(t *testing.T) {
+	web := newTestPod("default", "web", "node-1")
+	api := newTestPod("default", "api", "node-2")
+	client := FakeObjectsClient{
+		resources: namespacedTestResources,
+		objects:   []runtime.Object{&unstructured.Unstructured{Object: web}, &unstructured.Unstructured{Object: api}},
+	}
+	multiClient := NewFakeMultiClusterClient(client)
+
+	list := NewListTool(multiClient, DefaultOptions())
+	result, err := list.Handler(context.Background(), mcp.CallToolRequest{Params: mcp.CallToolParams{Arguments: map[string]any{
+		"kind":   "Pod",
+		"output": "name",
+	}}})
+	assert.NoError(t, err)
+	assert.Equal(t, "pod/api\npod/web\n", result.Content[0].(mcp.TextContent).Text)
+
+	result, err = list.Handler(context.Background(), mcp.CallToolRequest{Params: mcp.CallToolParams{Arguments: map[string]any{
+		"kind":   "Pod",
+		"fields": []any{".spec.nodeName"},
+		"output": "yaml",
+	}}})
+	assert.NoError(t, err)
+	assert.Equal(t, `items:
+- .spec.nodeName: node-2
+  name: api
+  namespace: default
+- .spec.nodeName: node-1
+  name: web
+  namespace: default
+`, result.Content[0].(mcp.TextContent).Text)
+
+	describe := NewDescribeTool(multiClient, DefaultOptions())
+	result, err = describe.Handler(context.Background(), mcp.CallToolRequest{Params: mcp.CallToolParams{Arguments: map[string]any{
+		"kind":      "Pod",
+		"name":      "web",
+		"namespace": "default",
+		"output":    "name",
+	}}})
+	assert.NoError(t, err)
+	assert.Equal(t, "pod/web\n", result.Content[0].(mcp.TextContent).Text)
+
+	// YAML output is redacted like JSON
+	result, err = describe.Handler(context.Background(), mcp.CallToolRequest{Params: mcp.CallToolParams{Arguments: map[string]any{
+		"kind":      "Pod",
+		"name":      "web",
+		"namespace": "default",
+		"output":    "yaml",
+	}}})
+	assert.NoError(t, err)
+	text := result.Content[0].(mcp.TextContent).Text
+	assert.Contains(t, text, "nodeName: node-1\n")
+	assert.Contains(t, text, "value: '[REDACTED]'")
+	assert.NotContains(t, text, "hunter2")
+	assert.Equal(t, 1, result.Meta[redactionsMetaKey])
+}
+
+func TestDescribeTool_Table(t *testing.T) {
+	release := newUnstructured("helm.toolkit.fluxcd.io/v2", "HelmRelease", "apps", "podinfo")
+	client := &TableObjectsClient{
+		FakeObjectsClient: FakeObjectsClient{resources: helmReleaseResources, objects: []runtime.Object{release}},
+		response: `{
+  "kind": "Table",
+  "apiVersion": "meta.k8s.io/v1",
+  "columnDefinitions": [
+    {"name": "Name", "type": "string", "format": "name", "priority": 0},
+    {"name": "Ready", "type": "string", "priority": 0}
+  ],
+  "rows": [
+    {
+      "cells": ["podinfo", "True"],
+      "object": {"kind": "PartialObjectMetadata", "apiVersion": "meta.k8s.io/v1", "metadata": {"name": "podinfo", "namespace": "apps"}}
+    }
+  ]
+}`,
+	}
+	d := NewDescribeTool(NewFakeMultiClusterClient(client), DefaultOptions())
+
+	result, err := d.Handler(context.Background(), mcp.CallToolRequest{Params: mcp.CallToolParams{Arguments: map[string]any{
+		"kind":      "HelmRelease",
+		"name":      "podinfo",
+		"namespace": "apps",
+		"output":    "table",
+	}}})
+	assert.NoError(t, err)
+	assert.Equal(t, "/apis/helm.toolkit.fluxcd.io/v2/namespaces/apps/helmreleases/podinfo", client.request.URL.Path)
+	assert.Equal(t, "NAME      READY\npodinfo   True\n", result.Content[0].(mcp.TextContent).Text)
+}
+
+func TestListEventsResult_Output(t *testing.T) {
+	result := ListEventsResult{
+		Events: []EventInfo{
+			{
+				Name:          "web.17a",
+				Namespace:     "default",
+				LastTimestamp: metav1.NewTime(time.Now().Add(-5 * time.Minute)),
+				Type:          "Warning",
+				Reason:        "BackOff",
+				Object:        "Pod/web",
+				Message:       "Back-off restarting failed container",
+			},
+			{
+				Name:      "api.17b",
+				Namespace: "apps",
+				Type:      "Normal",
+				Reason:    "Pulled",
+				Object:    "Pod/api",
+				Message:   "Container image already present",
+			},
+		},
+	}
+
+	assert.Equal(t, []string{"event/web.17a", "event/api.17b"}, result.names())
+
+	lines := strings.Split(string(result.table().render()), "\n")
+	assert.Equal(t, []string{"NAMESPACE", "LAST", "SEEN", "TYPE", "REASON", "OBJECT", "MESSAGE"}, strings.Fields(lines[0]))
+	assert.Equal(t, []string{"default", "5m", "Warning", "BackOff", "Pod/web"}, strings.Fields(lines[1])[:5])
+	assert.Equal(t, []string{"apps", "<unknown>", "Normal", "Pulled", "Pod/api"}, strings.Fields(lines[2])[:5])
+
+	result.Namespace = "default"
+	assert.Equal(t, []string{"LAST", "SEEN", "TYPE", "REASON", "OBJECT", "MESSAGE"}, strings.Fields(string(result.table().render()))[:6])
+}
@@ -0,0 +1,85 @@
+package tools
+
+import (
+	"context"
+	"testing"
+
+	"github.com/mark3labs/mcp-go/mcp"
+	"github.com/stretchr/testify/assert"
+	"k8s.io/apimachinery/pkg/apis/meta/v1/unstructured"
+	"k8s.io/apimachinery/pkg/runtime"
+)
+
+// newNoisyPod returns a pod with managedFields and a last-applied annotation
+func newNoisyPod() *unstructured.Unstructured {
+	pod := newUnstructured("v1", "Pod", "default", "web")
+	pod.SetAnnotations(map[string]string{lastAppliedAnnotation: `{"spec":{}}`, "team": "payments"})
+	pod.Object["metadata"].(map[string]any)["managedFields"] = []any{
+		map[string]any{"manager": "kubectl", "operation": "Apply"},
+	}
+	return pod
+}
+
+func TestPruning_Tools(t *testing.T) {
+	testCases := []struct {
+		name   string
+		tool   func(MultiClusterClientInterface) func(context.Context, mcp.CallToolRequest) (*mcp.CallToolResult, error)
+		args   map[string]any
+		pruned bool
+	}{
+		{
+			name: "list showDetails",
+			tool: func(c MultiClusterClientInterface) func(context.Context, mcp.CallToolRequest) (*mcp.CallToolResult, error) {
+				return NewListTool(c, DefaultOptions()).Handler
+			},
+			args:   map[string]any{"kind": "Pod", "showDetails": true},
+			pruned: true,
+		},
+		{
+			name: "list raw",
+			tool: func(c MultiClusterClientInterface) func(context.Context, mcp.CallToolRequest) (*mcp.CallToolResult, error) {
+				return NewListTool(c, DefaultOptions()).Handler
+			},
+			args: map[string]any{"kind": "Pod", "showDetails": true, "raw": true},
+		},
+		{
+			name: "describe",
+			tool: func(c MultiClusterClientInterface) func(context.Context, mcp.CallToolRequest) (*mcp.CallToolResult, error) {
+				return NewDescribeTool(c, DefaultOptions()).Handler
+			},
+			args:   map[string]any{"kind": "Pod", "name": "web", "namespace": "default", "output": "yaml"},
+			pruned: true,
+		},
+	}
+
+	for _, tc := range testCases {
+		t.Run(tc.name, func(t *testing.T) {
+			client := FakeObjectsClient{resources: namespacedTestResources, objects: []runtime.Object{newNoisyPod()}}
+			handler := tc.tool(NewFakeMultiClusterClient(client))
+
+			result, err := handler(context.Background(), mcp.CallToolRequest{Params: mcp.CallToolParams{Arguments: tc.args}})
+			assert.NoError(t, err)
+			text := result.Content[0].(mcp.TextContent).Text
+			assert.Contains(t, text, "payments")
+			if tc.pruned {
+				assert.NotContains(t, text, "managedFields")
+				assert.NotContains(t, text, lastAppliedAnnotation)
+				assert.Nil(t, result.Meta)
+			} else {
+				assert.Contains(t, text, "managedFields")
+				assert.Contains(t, text, lastAppliedAnnotation)
+			}
+		})
+	}
+}
+
+func TestPruning_Disabled(t *testing.T) {
+	opts := DefaultOptions()
+	opts.Pruner = nil
+	client := FakeObjectsClient{resources: namespacedTestResources, objects: []runtime.Object{newNoisyPod()}}
+	l := NewListTool(NewFakeMultiClusterClient(client), opts)
+
+	result, err := l.Handler(context.Background(), mcp.CallToolRequest{Params: mcp.CallToolParams{Arguments: map[string]any{"kind": "Pod", "showDetails": true}}})
+	assert.NoError(t, err)
+	assert.Contains(t, result.Content[0].(mcp.TextContent).Text, "managedFields")
+}
@@ -0,0 +1,81 @@
+package tools
+
+import (
+	"fmt"
+	"sort"
+
+	"k8s.io/apimachinery/pkg/apis/meta/v1/unstructured"
+	"k8s.io/apimachinery/pkg/runtime/schema"
+)
+
+// lastAppliedAnnotation holds the full object as last applied by kubectl, including any data values.
+const lastAppliedAnnotation = "kubectl.kubernetes.io/last-applied-configuration"
+
+// dataFields are the top-level fields whose keys are reported for metadata-only resources.
+var dataFields = []string{"data", "stringData", "binaryData"}
+
+// checkResource returns an error if the resource may not be read under the resource policy.
+func (o Options) checkResource(gvr schema.GroupVersionResource) error {
+	if o.Resources.Denied(gvr) {
+		return fmt.Errorf("resource '%s' is blocked by the server's resource policy", gvrString(gvr))
+	}
+	return nil
+}
+
+// gvrString formats the resource like kubectl does, e.g. "deployments.apps" or "secrets".
+func gvrString(gvr schema.GroupVersionResource) string {
+	if gvr.Group == "" {
+		return gvr.Resource
+	}
+	return gvr.Resource + "." + gvr.Group
+}
+
+// metadataOnlyObject returns a copy of obj reduced to its identity, metadata, type and the names of
+// the keys in its data fields. Spec, status and every data value are dropped.
+func metadataOnlyObject(obj *unstructured.Unstructured) *unstructured.Unstructured {
+	result := &unstructured.Unstructured{Object: map[string]any{}}
+	result.SetAPIVersion(obj.GetAPIVersion())
+	result.SetKind(obj.GetKind())
+	result.SetName(obj.GetName())
+	result.SetNamespace(obj.GetNamespace())
+	result.SetUID(obj.GetUID())
+	result.SetResourceVersion(obj.GetResourceVersion())
+	result.SetCreationTimestamp(obj.GetCreationTimestamp())
+	result.SetLabels(obj.GetLabels())
+	result.SetOwnerReferences(obj.GetOwnerReferences())
+
+	if annotations := obj.GetAnnotations(); len(annotations) > 0 {
+		kept := make(map[string]string, len(annotations))
+		for k, v := range annotations {
+			if k != lastAppliedAnnotation {
+				kept[k] = v
+			}
+		}
+		result.SetAnnotations(kept)
+	}
+
+	if objType, found, err := unstructured.NestedString(obj.Object, "type"); found && err == nil {
+		result.Object["type"] = objType
+	}
+
+	var keys []string
+	for _, field := range dataFields {
+		if data, found, err := unstructured.NestedFieldNoCopy(obj.Object, field); found && err == nil {
+			if m, ok := data.(map[string]any); ok {
+				for k := range m {
+					keys = append(keys, k)
+				}
+			}
+		}
+	}
+	if len(keys) > 0 {
+		sort.Strings(keys)
+		keyList := make([]any, 0, len(keys))
+		for _, k := range keys {
+			keyList = append(keyList, k)
+		}
+		result.Object["keys"] = keyList
+	}
+
+	return result
+}
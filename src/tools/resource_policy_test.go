@@ -0,0 +1,96 @@
+package tools
+
+import (
+	"context"
+	"encoding/json"
+	"testing"
+
+	"github.com/kkb0318/kubernetes-mcp/src/policy"
+	"github.com/mark3labs/mcp-go/mcp"
+	"github.com/stretchr/testify/assert"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/apis/meta/v1/unstructured"
+	"k8s.io/apimachinery/pkg/runtime"
+)
+
+func newTestSecret() *unstructured.Unstructured {
+	secret := newUnstructured("v1", "Secret", "default", "db-credentials")
+	secret.SetLabels(map[string]string{"app": "db"})
+	secret.SetAnnotations(map[string]string{
+		"owner":               "team-a",
+		lastAppliedAnnotation: `{"data":{"password":"c2VjcmV0"}}`,
+	})
+	secret.Object["type"] = "Opaque"
+	secret.Object["data"] = map[string]any{"password": "c2VjcmV0", "username": "YWRtaW4="}
+	secret.Object["stringData"] = map[string]any{"host": "db.internal"}
+	return secret
+}
+
+var secretTestResources = []*metav1.APIResourceList{
+	{
+		GroupVersion: "v1",
+		APIResources: []metav1.APIResource{
+			{Kind: "Secret", Name: "secrets", Namespaced: true},
+		},
+	},
+}
+
+func TestMetadataOnlyObject(t *testing.T) {
+	actual := metadataOnlyObject(newTestSecret())
+
+	assert.Equal(t, map[string]any{
+		"apiVersion": "v1",
+		"kind":       "Secret",
+		"metadata": map[string]any{
+			"name":        "db-credentials",
+			"namespace":   "default",
+			"labels":      map[string]any{"app": "db"},
+			"annotations": map[string]any{"owner": "team-a"},
+		},
+		"type": "Opaque",
+		"keys": []any{"host", "password", "username"},
+	}, actual.Object)
+}
+
+func TestResourcePolicy_Tools(t *testing.T) {
+	client := FakeObjectsClient{
+		resources: secretTestResources,
+		objects:   []runtime.Object{newTestSecret()},
+	}
+	multiClient := NewFakeMultiClusterClient(client)
+
+	// Secrets are blocked by default
+	list := NewListTool(multiClient, DefaultOptions())
+	_, err := list.Handler(context.Background(), mcp.CallToolRequest{Params: mcp.CallToolParams{Arguments: map[string]any{"kind": "Secret", "showDetails": true}}})
+	assert.Error(t, err)
+	assert.Contains(t, err.Error(), "resource 'secrets' is blocked by the server's resource policy")
+
+	describe := NewDescribeTool(multiClient, DefaultOptions())
+	_, err = describe.Handler(context.Background(), mcp.CallToolRequest{Params: mcp.CallToolParams{Arguments: map[string]any{"kind": "Secret", "name": "db-credentials", "namespace": "default"}}})
+	assert.Error(t, err)
+	assert.Contains(t, err.Error(), "resource 'secrets' is blocked")
+
+	// In metadata-only mode, names, types and key names are returned but never values
+	resources, err := policy.NewResourcePolicy(nil, []string{"secrets"})
+	assert.NoError(t, err)
+	opts := DefaultOptions()
+	opts.Resources = resources
+
+	list = NewListTool(multiClient, opts)
+	result, err := list.Handler(context.Background(), mcp.CallToolRequest{Params: mcp.CallToolParams{Arguments: map[string]any{"kind": "Secret", "showDetails": true}}})
+	assert.NoError(t, err)
+	text := result.Content[0].(mcp.TextContent).Text
+	assert.Contains(t, text, `"keys":["host","password","username"]`)
+	assert.NotContains(t, text, "c2VjcmV0")
+	assert.NotContains(t, text, "db.internal")
+
+	describe = NewDescribeTool(multiClient, opts)
+	result, err = describe.Handler(context.Background(), mcp.CallToolRequest{Params: mcp.CallToolParams{Arguments: map[string]any{"kind": "Secret", "name": "db-credentials", "namespace": "default"}}})
+	assert.NoError(t, err)
+	var description map[string]any
+	assert.NoError(t, json.Unmarshal([]byte(result.Content[0].(mcp.TextContent).Text), &description))
+	assert.Equal(t, "Opaque", description["type"])
+	assert.Equal(t, []any{"host", "password", "username"}, description["keys"])
+	assert.Equal(t, true, description["metadataOnly"])
+	assert.NotContains(t, result.Content[0].(mcp.TextContent).Text, "c2VjcmV0")
+}
@@ -0,0 +1,55 @@
+package tools
+
+import (
+	"encoding/json"
+	"fmt"
+
+	"github.com/mark3labs/mcp-go/mcp"
+)
+
+// redactionsMetaKey is the _meta field of a tool result holding the number of redacted values.
+const redactionsMetaKey = "redactions"
+
+// redactObject redacts a decoded object in place and returns the number of redacted values. Tools
+// that reshape objects, by projecting fields or grouping them, call it first: the reshaped
+// values lose the keys that identify them as sensitive, so they could no longer be redacted later.
+func (o Options) redactObject(obj map[string]any) int {
+	_, count := o.Redactor.Value(obj)
+	return count
+}
+
+// toolResult wraps a tool's output in a text result after redacting sensitive values.
+// Every tool returns its output through here, directly or through formatOutput, so that no value
+// bypasses redaction.
+// When anything was redacted, the count is reported in the result's _meta and in a second text content.
+func (o Options) toolResult(out []byte) (*mcp.CallToolResult, error) {
+	return o.redactedToolResult(out, 0)
+}
+
+// redactedToolResult is toolResult for output whose values were partly redacted before it was
+// serialized. The earlier redactions are added to the count reported to the client.
+func (o Options) redactedToolResult(out []byte, redactions int) (*mcp.CallToolResult, error) {
+	var count int
+	if json.Valid(out) {
+		var err error
+		out, count, err = o.Redactor.JSON(out)
+		if err != nil {
+			return nil, err
+		}
+	} else {
+		var text string
+		text, count = o.Redactor.String(string(out))
+		out = []byte(text)
+	}
+	return newToolResult(out, count+redactions), nil
+}
+
+// newToolResult wraps already redacted output in a text result and reports the redaction count.
+func newToolResult(out []byte, count int) *mcp.CallToolResult {
+	result := mcp.NewToolResultText(string(out))
+	if count > 0 {
+		result.Meta = map[string]any{redactionsMetaKey: count}
+		result.Content = append(result.Content, mcp.NewTextContent(fmt.Sprintf("%d sensitive value(s) were redacted by the server", count)))
+	}
+	return result
+}
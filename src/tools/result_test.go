@@ -0,0 +1,89 @@
+package tools
+
+import (
+	"context"
+	"testing"
+
+	"github.com/mark3labs/mcp-go/mcp"
+	"github.com/stretchr/testify/assert"
+	"k8s.io/apimachinery/pkg/runtime"
+)
+
+func TestToolResult(t *testing.T) {
+	testCases := []struct {
+		name               string
+		opts               Options
+		output             string
+		expectedText       string
+		expectedRedactions int
+	}{
+		{
+			name:         "nothing to redact",
+			opts:         DefaultOptions(),
+			output:       `[{"name":"foo","kind":"Deployment"}]`,
+			expectedText: `[{"name":"foo","kind":"Deployment"}]`,
+		},
+		{
+			name:               "json output",
+			opts:               DefaultOptions(),
+			output:             `{"logs":"login with password=hunter2\n","source":"current"}`,
+			expectedText:       `{"logs":"login with password=[REDACTED]\n","source":"current"}`,
+			expectedRedactions: 1,
+		},
+		{
+			name:               "text output",
+			opts:               DefaultOptions(),
+			output:             `{"message": "No resources found for group filter 'token=abc'"}x`,
+			expectedText:       `{"message": "No resources found for group filter 'token=[REDACTED]'"}x`,
+			expectedRedactions: 1,
+		},
+		{
+			name:         "redaction disabled",
+			opts:         Options{},
+			output:       `{"password":"hunter2"}`,
+			expectedText: `{"password":"hunter2"}`,
+		},
+	}
+
+	for _, tc := range testCases {
+		t.Run(tc.name, func(t *testing.T) {
+			result, err := tc.opts.toolResult([]byte(tc.output))
+			assert.NoError(t, err)
+			assert.Equal(t, tc.expectedText, result.Content[0].(mcp.TextContent).Text)
+			if tc.expectedRedactions == 0 {
+				assert.Len(t, result.Content, 1)
+				assert.Nil(t, result.Meta)
+				return
+			}
+			assert.Equal(t, map[string]any{redactionsMetaKey: tc.expectedRedactions}, result.Meta)
+			assert.Len(t, result.Content, 2)
+			assert.Contains(t, result.Content[1].(mcp.TextContent).Text, "redacted")
+		})
+	}
+}
+
+func TestToolResult_ListResources(t *testing.T) {
+	pod := newUnstructured("v1", "Pod", "default", "web")
+	pod.SetAnnotations(map[string]string{lastAppliedAnnotation: `{"spec":{}}`})
+	pod.Object["spec"] = map[string]any{
+		"containers": []any{
+			map[string]any{
+				"name": "web",
+				"env": []any{
+					map[string]any{"name": "DB_PASSWORD", "value": "hunter2"},
+					map[string]any{"name": "PORT", "value": "8080"},
+				},
+			},
+		},
+	}
+	client := FakeObjectsClient{resources: namespacedTestResources, objects: []runtime.Object{pod}}
+	l := NewListTool(NewFakeMultiClusterClient(client), DefaultOptions())
+
+	// raw keeps the last-applied annotation, so that it is redacted rather than pruned
+	result, err := l.Handler(context.Background(), mcp.CallToolRequest{Params: mcp.CallToolParams{Arguments: map[string]any{"kind": "Pod", "showDetails": true, "raw": true}}})
+	assert.NoError(t, err)
+	text := result.Content[0].(mcp.TextContent).Text
+	assert.NotContains(t, text, "hunter2")
+	assert.Contains(t, text, `"value":"8080"`)
+	assert.Equal(t, 2, result.Meta[redactionsMetaKey])
+}
@@ -0,0 +1,168 @@
+package tools
+
+import (
+	"cmp"
+	"encoding/json"
+	"fmt"
+	"slices"
+	"strings"
+
+	"github.com/mark3labs/mcp-go/mcp"
+	"k8s.io/apimachinery/pkg/api/resource"
+	"k8s.io/apimachinery/pkg/apis/meta/v1/unstructured"
+	"k8s.io/client-go/util/jsonpath"
+)
+
+// Sort orders selected with the "order" argument.
+const (
+	orderAsc  = "asc"
+	orderDesc = "desc"
+)
+
+// withOrder adds the "order" argument used together with "sortBy".
+func withOrder() mcp.ToolOption {
+	return mcp.WithString("order",
+		mcp.Description("Sort order: 'asc' (default) or 'desc'"),
+		mcp.Enum(orderAsc, orderDesc),
+	)
+}
+
+// parseOrder reads the "order" argument. An empty value selects ascending order.
+func parseOrder(args map[string]any) (string, error) {
+	order, _ := args["order"].(string)
+	switch order {
+	case "", orderAsc, orderDesc:
+		return order, nil
+	default:
+		return "", fmt.Errorf("invalid order %q: must be '%s' or '%s'", order, orderAsc, orderDesc)
+	}
+}
+
+// newSortKey parses a sortBy JSONPath expression, written like a field of the "fields" argument.
+func newSortKey(sortBy string) (*jsonpath.JSONPath, error) {
+	parser, err := parseFieldPath(sortBy)
+	if err != nil {
+		return nil, fmt.Errorf("invalid sortBy %q: %w", sortBy, err)
+	}
+	return parser, nil
+}
+
+// sortObjects sorts objects by the value at sortBy, like 'kubectl get --sort-by'. Objects without
+// a value are placed last in either order, and objects with equal values keep their order.
+func sortObjects(objects []unstructured.Unstructured, sortBy string, descending bool) error {
+	parser, err := newSortKey(sortBy)
+	if err != nil {
+		return err
+	}
+	type keyedObject struct {
+		object unstructured.Unstructured
+		key    any
+	}
+	keyed := make([]keyedObject, len(objects))
+	for i, obj := range objects {
+		keyed[i].object = obj
+		values, err := fieldValues(parser, obj.Object)
+		if err != nil {
+			return fmt.Errorf("failed to evaluate sortBy %q: %w", sortBy, err)
+		}
+		if len(values) > 0 {
+			keyed[i].key = values[0]
+		}
+	}
+
+	slices.SortStableFunc(keyed, func(a, b keyedObject) int {
+		return compareSortValues(a.key, b.key, descending)
+	})
+	for i := range keyed {
+		objects[i] = keyed[i].object
+	}
+	return nil
+}
+
+// Ranks of the sort key types. Keys of different types are ordered by rank, so that mixed keys
+// still sort consistently.
+const (
+	sortRankNumber = iota
+	sortRankBool
+	sortRankString
+	sortRankOther
+)
+
+// compareSortValues compares two sort keys. Numbers, including quantities such as "500m", compare
+// numerically, booleans false first and strings as text, which orders RFC 3339 timestamps by time.
+// Keys of different types are ordered numbers, booleans, strings, then lists and objects, and
+// missing values sort last.
+func compareSortValues(a, b any, descending bool) int {
+	switch {
+	case a == nil && b == nil:
+		return 0
+	case a == nil:
+		return 1
+	case b == nil:
+		return -1
+	}
+
+	c := compareSortKeys(a, b)
+	if descending {
+		return -c
+	}
+	return c
+}
+
+// compareSortKeys compares two non-nil sort keys by rank, then within the rank.
+func compareSortKeys(a, b any) int {
+	x, xRank := sortRank(a)
+	y, yRank := sortRank(b)
+	if xRank != yRank {
+		return cmp.Compare(xRank, yRank)
+	}
+	switch xRank {
+	case sortRankNumber, sortRankBool:
+		return cmp.Compare(x, y)
+	case sortRankString:
+		return strings.Compare(a.(string), b.(string))
+	default:
+		return strings.Compare(fmt.Sprint(a), fmt.Sprint(b))
+	}
+}
+
+// sortRank returns the rank of a sort key, with its numeric value for numbers and booleans.
+func sortRank(v any) (float64, int) {
+	if n, ok := sortNumber(v); ok {
+		return n, sortRankNumber
+	}
+	switch v := v.(type) {
+	case bool:
+		if v {
+			return 1, sortRankBool
+		}
+		return 0, sortRankBool
+	case string:
+		return 0, sortRankString
+	}
+	return 0, sortRankOther
+}
+
+// sortNumber converts numeric sort keys, and strings holding a resource quantity, to a float64.
+func sortNumber(v any) (float64, bool) {
+	switch n := v.(type) {
+	case int64:
+		return float64(n), true
+	case int32:
+		return float64(n), true
+	case int:
+		return float64(n), true
+	case float64:
+		return n, true
+	case json.Number:
+		f, err := n.Float64()
+		return f, err == nil
+	case string:
+		q, err := resource.ParseQuantity(n)
+		if err != nil {
+			return 0, false
+		}
+		return q.AsApproximateFloat64(), true
+	}
+	return 0, false
+}
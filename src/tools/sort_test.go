@@ -0,0 +1,134 @@
+package tools
+
+import (
+	"context"
+	"encoding/json"
+	"slices"
+	"testing"
+	"time"
+
+	"github.com/mark3labs/mcp-go/mcp"
+	"github.com/stretchr/testify/assert"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/apis/meta/v1/unstructured"
+	"k8s.io/apimachinery/pkg/runtime"
+)
+
+// newSortTestPod returns a pod with a creation timestamp, a restart count and a memory request
+func newSortTestPod(name, created string, restarts any, memory string) *unstructured.Unstructured {
+	pod := newUnstructured("v1", "Pod", "default", name)
+	pod.Object["metadata"].(map[string]any)["creationTimestamp"] = created
+	pod.Object["spec"] = map[string]any{
+		"containers": []any{map[string]any{"name": "app", "resources": map[string]any{"requests": map[string]any{"memory": memory}}}},
+	}
+	if restarts != nil {
+		pod.Object["status"] = map[string]any{
+			"containerStatuses": []any{map[string]any{"name": "app", "restartCount": restarts}},
+		}
+	}
+	return pod
+}
+
+func sortTestPods() []unstructured.Unstructured {
+	return []unstructured.Unstructured{
+		*newSortTestPod("a", "2025-06-02T10:00:00Z", int64(3), "1Gi"),
+		*newSortTestPod("b", "2025-06-01T10:00:00Z", nil, "512Mi"),
+		*newSortTestPod("c", "2025-06-03T10:00:00Z", int64(12), "2Gi"),
+	}
+}
+
+func TestSortObjects(t *testing.T) {
+	testCases := []struct {
+		name       string
+		sortBy     string
+		descending bool
+		expected   []string
+	}{
+		{name: "timestamp", sortBy: ".metadata.creationTimestamp", expected: []string{"b", "a", "c"}},
+		{name: "timestamp descending", sortBy: "{.metadata.creationTimestamp}", descending: true, expected: []string{"c", "a", "b"}},
+		{name: "number with missing value", sortBy: ".status.containerStatuses[0].restartCount", expected: []string{"a", "c", "b"}},
+		{name: "number descending keeps missing last", sortBy: ".status.containerStatuses[0].restartCount", descending: true, expected: []string{"c", "a", "b"}},
+		{name: "quantity", sortBy: "spec.containers[0].resources.requests.memory", expected: []string{"b", "a", "c"}},
+		{name: "name", sortBy: ".metadata.name", descending: true, expected: []string{"c", "b", "a"}},
+	}
+
+	for _, tc := range testCases {
+		t.Run(tc.name, func(t *testing.T) {
+			pods := sortTestPods()
+			assert.NoError(t, sortObjects(pods, tc.sortBy, tc.descending))
+			var names []string
+			for _, pod := range pods {
+				names = append(names, pod.GetName())
+			}
+			assert.Equal(t, tc.expected, names)
+		})
+	}
+}
+
+func TestListTool_SortBy(t *testing.T) {
+	var objects []runtime.Object
+	for _, pod := range sortTestPods() {
+		objects = append(objects, pod.DeepCopy())
+	}
+	client := FakeObjectsClient{resources: namespacedTestResources, objects: objects}
+	l := NewListTool(NewFakeMultiClusterClient(client), DefaultOptions())
+
+	result, err := l.Handler(context.Background(), mcp.CallToolRequest{Params: mcp.CallToolParams{Arguments: map[string]any{
+		"kind":   "Pod",
+		"sortBy": ".status.containerStatuses[0].restartCount",
+		"order":  "desc",
+		"output": "name",
+	}}})
+	assert.NoError(t, err)
+	assert.Equal(t, "pod/c\npod/a\npod/b\n", result.Content[0].(mcp.TextContent).Text)
+
+	for _, args := range []map[string]any{
+		{"kind": "Pod", "order": "desc"},
+		{"kind": "Pod", "sortBy": ".metadata.name", "order": "newest"},
+		{"kind": "Pod", "sortBy": ".status.containerStatuses[*"},
+		{"kind": "Pod", "sortBy": ".metadata.name", "output": "table"},
+	} {
+		_, err := l.Handler(context.Background(), mcp.CallToolRequest{Params: mcp.CallToolParams{Arguments: args}})
+		assert.Error(t, err, args)
+	}
+}
+
+func TestSortEventInfos(t *testing.T) {
+	now := time.Now()
+	events := []EventInfo{
+		{Name: "a", Count: 5, LastTimestamp: metav1.NewTime(now.Add(-time.Hour))},
+		{Name: "b", Count: 1, FirstTimestamp: metav1.NewTime(now.Add(-time.Minute))},
+		{Name: "c", Count: 9, LastTimestamp: metav1.NewTime(now.Add(-2 * time.Hour))},
+	}
+	names := func() []string {
+		var names []string
+		for _, event := range events {
+			names = append(names, event.Name)
+		}
+		return names
+	}
+
+	sortEventInfos(events, eventsSortByLastTimestamp, false)
+	assert.Equal(t, []string{"c", "a", "b"}, names())
+
+	sortEventInfos(events, eventsSortByCount, true)
+	assert.Equal(t, []string{"c", "a", "b"}, names())
+
+	sortEventInfos(events, eventsSortByCount, false)
+	assert.Equal(t, []string{"b", "a", "c"}, names())
+}
+
+func TestCompareSortValues_MixedTypes(t *testing.T) {
+	values := []any{"b", map[string]any{"a": "b"}, true, int64(10), nil, "500m", "a", false, json.Number("2"), []any{"x"}}
+	expected := []any{"500m", json.Number("2"), int64(10), false, true, "a", "b", []any{"x"}, map[string]any{"a": "b"}, nil}
+
+	slices.SortStableFunc(values, func(a, b any) int { return compareSortValues(a, b, false) })
+	assert.Equal(t, expected, values)
+
+	// The order is transitive across types, so sorting any permutation gives the same result
+	for i := range values {
+		shuffled := append(slices.Clone(values[i:]), values[:i]...)
+		slices.SortStableFunc(shuffled, func(a, b any) int { return compareSortValues(a, b, false) })
+		assert.Equal(t, expected, shuffled)
+	}
+}
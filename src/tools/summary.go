@@ -0,0 +1,256 @@
+package tools
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+	"slices"
+	"strconv"
+	"strings"
+
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/apis/meta/v1/unstructured"
+	"k8s.io/apimachinery/pkg/runtime/schema"
+	"k8s.io/client-go/util/jsonpath"
+)
+
+// summaryPageSize is the number of objects fetched per request when summarizing a resource.
+const summaryPageSize = 500
+
+// metadataListAcceptHeader asks the API server for a PartialObjectMetadataList, which holds only
+// the metadata of each object, falling back to the full list for servers that cannot.
+const metadataListAcceptHeader = "application/json;as=PartialObjectMetadataList;v=v1;g=meta.k8s.io,application/json"
+
+// noGroupValue is the group of objects the groupBy field matches nothing in.
+const noGroupValue = "<none>"
+
+// ListSummary is the result of list_resources in summary mode: object counts per namespace,
+// optionally split by the value of the groupBy field.
+type ListSummary struct {
+	Kind       string             `json:"kind"`
+	GroupBy    string             `json:"groupBy,omitempty"`
+	Total      int                `json:"total"`
+	Namespaces []NamespaceSummary `json:"namespaces"`
+}
+
+// NamespaceSummary counts the objects of one namespace. Cluster-scoped resources have a single
+// summary without a namespace.
+type NamespaceSummary struct {
+	Namespace string         `json:"namespace,omitempty"`
+	Total     int            `json:"total"`
+	Groups    map[string]int `json:"groups,omitempty"`
+}
+
+// table prints one row per namespace, or per namespace and group with groupBy.
+func (s ListSummary) table() textTable {
+	var t textTable
+	namespaced := slices.ContainsFunc(s.Namespaces, func(ns NamespaceSummary) bool { return ns.Namespace != "" })
+	if namespaced {
+		t.headers = append(t.headers, "Namespace")
+	}
+	if s.GroupBy != "" {
+		t.headers = append(t.headers, "Group")
+	}
+	t.headers = append(t.headers, "Count")
+
+	for _, ns := range s.Namespaces {
+		var prefix []string
+		if namespaced {
+			prefix = append(prefix, ns.Namespace)
+		}
+		if s.GroupBy == "" {
+			t.rows = append(t.rows, append(prefix, strconv.Itoa(ns.Total)))
+			continue
+		}
+		groups := make([]string, 0, len(ns.Groups))
+		for group := range ns.Groups {
+			groups = append(groups, group)
+		}
+		slices.Sort(groups)
+		for _, group := range groups {
+			row := append(slices.Clone(prefix), group, strconv.Itoa(ns.Groups[group]))
+			t.rows = append(t.rows, row)
+		}
+	}
+	t.footer = []string{fmt.Sprintf("total: %d", s.Total)}
+	return t
+}
+
+// summarizer accumulates the counts of a ListSummary.
+type summarizer struct {
+	groupBy    *jsonpath.JSONPath
+	namespaces map[string]*NamespaceSummary
+	summary    ListSummary
+}
+
+// add counts obj in its namespace and group.
+func (s *summarizer) add(namespace string, obj map[string]any) error {
+	ns, ok := s.namespaces[namespace]
+	if !ok {
+		ns = &NamespaceSummary{Namespace: namespace}
+		s.namespaces[namespace] = ns
+	}
+	ns.Total++
+	s.summary.Total++
+	if s.groupBy == nil {
+		return nil
+	}
+
+	values, err := fieldValues(s.groupBy, obj)
+	if err != nil {
+		return fmt.Errorf("failed to evaluate groupBy %q: %w", s.summary.GroupBy, err)
+	}
+	if ns.Groups == nil {
+		ns.Groups = map[string]int{}
+	}
+	ns.Groups[groupValue(values)]++
+	return nil
+}
+
+// result returns the summary with its namespaces sorted by name.
+func (s *summarizer) result() ListSummary {
+	summary := s.summary
+	summary.Namespaces = make([]NamespaceSummary, 0, len(s.namespaces))
+	for _, ns := range s.namespaces {
+		summary.Namespaces = append(summary.Namespaces, *ns)
+	}
+	slices.SortFunc(summary.Namespaces, func(a, b NamespaceSummary) int {
+		return strings.Compare(a.Namespace, b.Namespace)
+	})
+	return summary
+}
+
+// groupValue formats the values a groupBy field matched as a group name.
+func groupValue(values []any) string {
+	if len(values) == 0 {
+		return noGroupValue
+	}
+	parts := make([]string, 0, len(values))
+	for _, value := range values {
+		switch value.(type) {
+		case map[string]any, []any:
+			out, _ := json.Marshal(value)
+			parts = append(parts, string(out))
+		default:
+			parts = append(parts, fmt.Sprint(value))
+		}
+	}
+	return strings.Join(parts, ",")
+}
+
+// isMetadataField reports whether a groupBy field only reads the object's metadata, so that
+// objects can be listed without their spec and status.
+func isMetadataField(field string) bool {
+	return strings.HasPrefix(relaxedJSONPath(field), "{.metadata.")
+}
+
+// listSummary counts the resources matching gvrMatch without returning them. Objects are listed
+// page by page, and only their metadata is fetched unless groupBy reads other fields. It also
+// returns the number of redacted values.
+func (l ListTool) listSummary(ctx context.Context, client Client, gvrMatch *gvrMatch, input *ListResourcesInput) (ListSummary, int, error) {
+	access, err := l.checkList(gvrMatch, input)
+	if err != nil {
+		return ListSummary{}, 0, err
+	}
+	gvr := gvrMatch.ToGroupVersionResource()
+
+	s := &summarizer{
+		namespaces: map[string]*NamespaceSummary{},
+		summary:    ListSummary{Kind: gvrMatch.apiRes.Kind, GroupBy: input.GroupBy},
+	}
+	if input.GroupBy != "" {
+		if s.groupBy, err = parseFieldPath(input.GroupBy); err != nil {
+			return ListSummary{}, 0, fmt.Errorf("invalid groupBy %q: %w", input.GroupBy, err)
+		}
+	}
+	metadataListing := input.GroupBy == "" || isMetadataField(input.GroupBy)
+	isNamespaceResource := gvr.Group == "" && gvr.Resource == "namespaces"
+
+	listOptions := l.buildListOptions(input)
+	listOptions.Limit = summaryPageSize
+	redactions := 0
+	for {
+		var objects []map[string]any
+		if metadataListing {
+			objects, listOptions.Continue, err = listMetadata(ctx, client, *gvr, gvrMatch.namespaced, input.Namespace, listOptions)
+		} else {
+			objects, listOptions.Continue, err = listPage(ctx, client, gvrMatch, input.Namespace, listOptions)
+		}
+		if err != nil {
+			return ListSummary{}, 0, err
+		}
+
+		for _, obj := range objects {
+			item := &unstructured.Unstructured{Object: obj}
+			namespace := item.GetNamespace()
+			if isNamespaceResource {
+				namespace = item.GetName()
+			}
+			if !access.allowed(namespace) {
+				continue
+			}
+			if l.opts.Resources.MetadataOnly(*gvr) {
+				item = metadataOnlyObject(item)
+			}
+			if s.groupBy != nil {
+				redactions += l.opts.redactObject(item.Object)
+			}
+			if err := s.add(item.GetNamespace(), item.Object); err != nil {
+				return ListSummary{}, 0, err
+			}
+		}
+
+		if listOptions.Continue == "" {
+			break
+		}
+	}
+
+	return s.result(), redactions, nil
+}
+
+// listPage lists one page of complete objects with the dynamic client.
+func listPage(ctx context.Context, client Client, gvrMatch *gvrMatch, namespace string, listOptions metav1.ListOptions) ([]map[string]any, string, error) {
+	ri, err := client.ResourceInterface(*gvrMatch.ToGroupVersionResource(), gvrMatch.namespaced, namespace)
+	if err != nil {
+		return nil, "", fmt.Errorf("failed to create resource interface: %w", err)
+	}
+	list, err := ri.List(ctx, listOptions)
+	if err != nil {
+		return nil, "", fmt.Errorf("failed to list resources: %w", err)
+	}
+	objects := make([]map[string]any, 0, len(list.Items))
+	for _, item := range list.Items {
+		objects = append(objects, item.Object)
+	}
+	return objects, list.GetContinue(), nil
+}
+
+// listMetadata lists one page of objects as PartialObjectMetadata, which carries only their
+// metadata and keeps the response small for large resources.
+func listMetadata(ctx context.Context, client Client, gvr schema.GroupVersionResource, namespaced bool, namespace string, listOptions metav1.ListOptions) ([]map[string]any, string, error) {
+	restClient, err := client.RESTClient()
+	if err != nil {
+		return nil, "", fmt.Errorf("failed to create REST client: %w", err)
+	}
+
+	data, err := restClient.Get().
+		AbsPath(apiPath(gvr)...).
+		NamespaceIfScoped(namespace, namespaced && namespace != "").
+		Resource(gvr.Resource).
+		VersionedParams(&listOptions, metav1.ParameterCodec).
+		SetHeader("Accept", metadataListAcceptHeader).
+		Do(ctx).
+		Raw()
+	if err != nil {
+		return nil, "", fmt.Errorf("failed to list resources: %w", err)
+	}
+
+	var list struct {
+		Metadata metav1.ListMeta  `json:"metadata"`
+		Items    []map[string]any `json:"items"`
+	}
+	if err := json.Unmarshal(data, &list); err != nil {
+		return nil, "", fmt.Errorf("failed to decode resource list: %w", err)
+	}
+	return list.Items, list.Metadata.Continue, nil
+}
@@ -0,0 +1,144 @@
+package tools
+
+import (
+	"bytes"
+	"context"
+	"io"
+	"net/http"
+	"testing"
+
+	"github.com/kkb0318/kubernetes-mcp/src/policy"
+	"github.com/mark3labs/mcp-go/mcp"
+	"github.com/stretchr/testify/assert"
+	"k8s.io/apimachinery/pkg/runtime"
+	"k8s.io/client-go/kubernetes/scheme"
+	"k8s.io/client-go/rest"
+	"k8s.io/client-go/rest/fake"
+)
+
+// MetadataListClient serves PartialObjectMetadataList pages keyed by continue token and records
+// every request
+type MetadataListClient struct {
+	FakeObjectsClient
+	pages    map[string]string
+	requests []*http.Request
+}
+
+func (c *MetadataListClient) RESTClient() (rest.Interface, error) {
+	return &fake.RESTClient{
+		NegotiatedSerializer: scheme.Codecs.WithoutConversion(),
+		Client: fake.CreateHTTPClient(func(req *http.Request) (*http.Response, error) {
+			c.requests = append(c.requests, req)
+			return &http.Response{
+				StatusCode: http.StatusOK,
+				Header:     http.Header{"Content-Type": []string{"application/json"}},
+				Body:       io.NopCloser(bytes.NewBufferString(c.pages[req.URL.Query().Get("continue")])),
+			}, nil
+		}),
+	}, nil
+}
+
+// newPhasePod returns a pod in the given phase
+func newPhasePod(namespace, name, phase string) runtime.Object {
+	pod := newUnstructured("v1", "Pod", namespace, name)
+	pod.Object["status"] = map[string]any{"phase": phase}
+	return pod
+}
+
+func TestListTool_SummaryGroupBy(t *testing.T) {
+	client := FakeObjectsClient{
+		resources: namespacedTestResources,
+		objects: []runtime.Object{
+			newPhasePod("apps", "web-1", "Running"),
+			newPhasePod("apps", "web-2", "Running"),
+			newPhasePod("apps", "job-1", "Succeeded"),
+			newPhasePod("default", "debug", "Pending"),
+			newUnstructured("v1", "Pod", "default", "new"),
+		},
+	}
+	l := NewListTool(NewFakeMultiClusterClient(client), DefaultOptions())
+
+	result, err := l.Handler(context.Background(), mcp.CallToolRequest{Params: mcp.CallToolParams{Arguments: map[string]any{
+		"kind":    "Pod",
+		"summary": true,
+		"groupBy": ".status.phase",
+	}}})
+	assert.NoError(t, err)
+	assert.Equal(t,
+		`{"kind":"Pod","groupBy":".status.phase","total":5,"namespaces":[{"namespace":"apps","total":3,"groups":{"Running":2,"Succeeded":1}},{"namespace":"default","total":2,"groups":{"<none>":1,"Pending":1}}]}`,
+		result.Content[0].(mcp.TextContent).Text)
+
+	result, err = l.Handler(context.Background(), mcp.CallToolRequest{Params: mcp.CallToolParams{Arguments: map[string]any{
+		"kind":    "Pod",
+		"summary": true,
+		"groupBy": ".status.phase",
+		"output":  "table",
+	}}})
+	assert.NoError(t, err)
+	assert.Equal(t, `NAMESPACE   GROUP       COUNT
+apps        Running     2
+apps        Succeeded   1
+default     <none>      1
+default     Pending     1
+
+total: 5
+`, result.Content[0].(mcp.TextContent).Text)
+}
+
+func TestListTool_SummaryMetadata(t *testing.T) {
+	namespaces, err := policy.NewNamespacePolicy([]policy.NamespaceRule{{Denied: []string{"kube-*"}}})
+	assert.NoError(t, err)
+	opts := DefaultOptions()
+	opts.Namespaces = namespaces
+
+	client := &MetadataListClient{
+		FakeObjectsClient: FakeObjectsClient{resources: namespacedTestResources},
+		pages: map[string]string{
+			"": `{"kind": "PartialObjectMetadataList", "apiVersion": "meta.k8s.io/v1", "metadata": {"continue": "page-2"}, "items": [
+  {"metadata": {"name": "web-1", "namespace": "apps", "labels": {"app": "web"}}},
+  {"metadata": {"name": "coredns", "namespace": "kube-system", "labels": {"app": "dns"}}}
+]}`,
+			"page-2": `{"kind": "PartialObjectMetadataList", "apiVersion": "meta.k8s.io/v1", "metadata": {}, "items": [
+  {"metadata": {"name": "web-2", "namespace": "apps", "labels": {"app": "web"}}},
+  {"metadata": {"name": "api", "namespace": "default"}}
+]}`,
+		},
+	}
+	l := NewListTool(NewFakeMultiClusterClient(client), opts)
+
+	result, err := l.Handler(context.Background(), mcp.CallToolRequest{Params: mcp.CallToolParams{Arguments: map[string]any{
+		"kind":    "Pod",
+		"summary": true,
+		"groupBy": "metadata.labels.app",
+	}}})
+	assert.NoError(t, err)
+	assert.Equal(t,
+		`{"kind":"Pod","groupBy":"metadata.labels.app","total":3,"namespaces":[{"namespace":"apps","total":2,"groups":{"web":2}},{"namespace":"default","total":1,"groups":{"<none>":1}}]}`,
+		result.Content[0].(mcp.TextContent).Text)
+
+	assert.Len(t, client.requests, 2)
+	for _, req := range client.requests {
+		assert.Equal(t, "/api/v1/pods", req.URL.Path)
+		assert.Equal(t, "500", req.URL.Query().Get("limit"))
+		assert.Equal(t, metadataListAcceptHeader, req.Header.Get("Accept"))
+	}
+	assert.Equal(t, "page-2", client.requests[1].URL.Query().Get("continue"))
+}
+
+func TestParseAndValidateListParams_Summary(t *testing.T) {
+	input, err := parseAndValidateListParams(map[string]any{"kind": "Pod", "summary": true, "groupBy": ".status.phase"}, DefaultOptions())
+	assert.NoError(t, err)
+	assert.True(t, input.Summary)
+	assert.Equal(t, ".status.phase", input.GroupBy)
+
+	for _, args := range []map[string]any{
+		{"kind": "Pod", "groupBy": ".status.phase"},
+		{"kind": "Pod", "summary": true, "groupBy": ".status.conditions[*"},
+		{"kind": "Pod", "summary": true, "showDetails": true},
+		{"kind": "Pod", "summary": true, "limit": float64(10)},
+		{"kind": "Pod", "summary": true, "output": "name"},
+	} {
+		_, err := parseAndValidateListParams(args, DefaultOptions())
+		assert.Error(t, err, args)
+	}
+}
@@ -0,0 +1,194 @@
+package tools
+
+import (
+	"bytes"
+	"context"
+	"encoding/json"
+	"fmt"
+	"strings"
+	"text/tabwriter"
+
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/runtime/schema"
+)
+
+// tableAcceptHeader asks the API server to render lists as a meta.k8s.io/v1 Table, the format
+// kubectl get prints, falling back to plain JSON for servers that cannot.
+const tableAcceptHeader = "application/json;as=Table;v=v1;g=meta.k8s.io,application/json"
+
+// textTable is tool output printed as tab-aligned columns, between an optional title line and
+// optional footer lines.
+type textTable struct {
+	title   string
+	headers []string
+	rows    [][]string
+	footer  []string
+}
+
+// table lets a textTable be passed to formatOutput as is.
+func (t textTable) table() textTable {
+	return t
+}
+
+// render prints the table with upper-case headers like kubectl.
+func (t textTable) render() []byte {
+	var buf bytes.Buffer
+	if t.title != "" {
+		buf.WriteString("# " + t.title + "\n")
+	}
+
+	if len(t.headers) > 0 {
+		w := tabwriter.NewWriter(&buf, 0, 8, 3, ' ', 0)
+		headers := make([]string, len(t.headers))
+		for i, header := range t.headers {
+			headers[i] = strings.ToUpper(header)
+		}
+		fmt.Fprintln(w, strings.Join(headers, "\t"))
+		for _, row := range t.rows {
+			fmt.Fprintln(w, strings.Join(row, "\t"))
+		}
+		_ = w.Flush()
+	}
+
+	if len(t.footer) > 0 {
+		if len(t.headers) > 0 {
+			buf.WriteString("\n")
+		}
+		for _, line := range t.footer {
+			buf.WriteString(line + "\n")
+		}
+	}
+	return buf.Bytes()
+}
+
+// serverTableRequest describes a list or get rendered by the API server as a Table.
+type serverTableRequest struct {
+	gvr        schema.GroupVersionResource
+	namespaced bool
+	namespace  string
+	// name selects a single object; empty lists the resource
+	name         string
+	listOptions  metav1.ListOptions
+	access       namespaceAccess
+	metadataOnly bool
+}
+
+// serverTable fetches the server-side Table kubectl get shows, including the
+// additionalPrinterColumns of CRDs, and converts it to a textTable. Rows in namespaces blocked by
+// the namespace policy are dropped, and a NAMESPACE column is added when listing across namespaces.
+func serverTable(ctx context.Context, client Client, req serverTableRequest) (textTable, error) {
+	restClient, err := client.RESTClient()
+	if err != nil {
+		return textTable{}, fmt.Errorf("failed to create REST client: %w", err)
+	}
+
+	request := restClient.Get().
+		AbsPath(apiPath(req.gvr)...).
+		NamespaceIfScoped(req.namespace, req.namespaced && req.namespace != "").
+		Resource(req.gvr.Resource).
+		Param("includeObject", string(metav1.IncludeMetadata)).
+		SetHeader("Accept", tableAcceptHeader)
+	if req.name != "" {
+		request = request.Name(req.name)
+	} else {
+		request = request.VersionedParams(&req.listOptions, metav1.ParameterCodec)
+	}
+	data, err := request.Do(ctx).Raw()
+	if err != nil {
+		return textTable{}, fmt.Errorf("failed to get resources as a table: %w", err)
+	}
+
+	table, err := decodeTable(data)
+	if err != nil {
+		return textTable{}, err
+	}
+
+	isNamespaceResource := req.gvr.Group == "" && req.gvr.Resource == "namespaces"
+	withNamespace := req.namespaced && req.namespace == "" && req.name == ""
+	columns := visibleColumns(table.ColumnDefinitions, req.metadataOnly)
+
+	var result textTable
+	if withNamespace {
+		result.headers = append(result.headers, "Namespace")
+	}
+	for _, i := range columns {
+		result.headers = append(result.headers, table.ColumnDefinitions[i].Name)
+	}
+	for _, row := range table.Rows {
+		var object metav1.PartialObjectMetadata
+		if err := json.Unmarshal(row.Object.Raw, &object); err != nil {
+			return textTable{}, fmt.Errorf("failed to decode table row: %w", err)
+		}
+		namespace := object.Namespace
+		if isNamespaceResource {
+			namespace = object.Name
+		}
+		if !req.access.allowed(namespace) {
+			continue
+		}
+
+		var cells []string
+		if withNamespace {
+			cells = append(cells, object.Namespace)
+		}
+		for _, i := range columns {
+			cells = append(cells, formatCell(row.Cells, i))
+		}
+		result.rows = append(result.rows, cells)
+	}
+
+	if table.Continue != "" {
+		result.footer = append(result.footer, "continue: "+table.Continue)
+		if table.RemainingItemCount != nil && !req.access.filtersList(req.namespaced, req.namespace, isNamespaceResource) {
+			result.footer = append(result.footer, fmt.Sprintf("remainingItemCount: %d", *table.RemainingItemCount))
+		}
+	}
+	return result, nil
+}
+
+// apiPath returns the path prefix of the API group version serving gvr.
+func apiPath(gvr schema.GroupVersionResource) []string {
+	if gvr.Group == "" {
+		return []string{"/api", gvr.Version}
+	}
+	return []string{"/apis", gvr.Group, gvr.Version}
+}
+
+// decodeTable decodes a Table response, keeping numeric cells as written by the server.
+func decodeTable(data []byte) (*metav1.Table, error) {
+	decoder := json.NewDecoder(bytes.NewReader(data))
+	decoder.UseNumber()
+	table := &metav1.Table{}
+	if err := decoder.Decode(table); err != nil {
+		return nil, fmt.Errorf("failed to decode table: %w", err)
+	}
+	if table.Kind != "Table" {
+		return nil, fmt.Errorf("the server did not return a table for this resource")
+	}
+	return table, nil
+}
+
+// visibleColumns returns the indexes of the columns kubectl get shows without -o wide.
+// For metadata-only resources only the name and age are shown, since other columns may
+// be derived from the object's data.
+func visibleColumns(definitions []metav1.TableColumnDefinition, metadataOnly bool) []int {
+	var columns []int
+	for i, column := range definitions {
+		if column.Priority != 0 {
+			continue
+		}
+		if metadataOnly && column.Format != "name" && !strings.EqualFold(column.Name, "Age") {
+			continue
+		}
+		columns = append(columns, i)
+	}
+	return columns
+}
+
+// formatCell formats a table cell like kubectl, printing "<none>" for missing values.
+func formatCell(cells []any, i int) string {
+	if i >= len(cells) || cells[i] == nil {
+		return "<none>"
+	}
+	return fmt.Sprint(cells[i])
+}
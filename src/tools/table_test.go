@@ -0,0 +1,145 @@
+package tools
+
+import (
+	"bytes"
+	"context"
+	"io"
+	"net/http"
+	"testing"
+
+	"github.com/kkb0318/kubernetes-mcp/src/policy"
+	"github.com/mark3labs/mcp-go/mcp"
+	"github.com/stretchr/testify/assert"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/client-go/kubernetes/scheme"
+	"k8s.io/client-go/rest"
+	"k8s.io/client-go/rest/fake"
+)
+
+// helmReleaseTable is a Table as returned by the API server for a CRD with additionalPrinterColumns
+const helmReleaseTable = `{
+  "kind": "Table",
+  "apiVersion": "meta.k8s.io/v1",
+  "metadata": {"continue": "next", "remainingItemCount": 5},
+  "columnDefinitions": [
+    {"name": "Name", "type": "string", "format": "name", "priority": 0},
+    {"name": "Age", "type": "date", "priority": 0},
+    {"name": "Ready", "type": "string", "priority": 0},
+    {"name": "Status", "type": "string", "priority": 0},
+    {"name": "Revision", "type": "integer", "priority": 0},
+    {"name": "Chart", "type": "string", "priority": 1}
+  ],
+  "rows": [
+    {
+      "cells": ["podinfo", "5d", "True", "Helm install succeeded", 12, "podinfo"],
+      "object": {"kind": "PartialObjectMetadata", "apiVersion": "meta.k8s.io/v1", "metadata": {"name": "podinfo", "namespace": "apps"}}
+    },
+    {
+      "cells": ["cilium", "30d", "False", null, 3, "cilium"],
+      "object": {"kind": "PartialObjectMetadata", "apiVersion": "meta.k8s.io/v1", "metadata": {"name": "cilium", "namespace": "kube-system"}}
+    }
+  ]
+}`
+
+// TableObjectsClient serves a fixed response to raw REST requests and records the last request
+type TableObjectsClient struct {
+	FakeObjectsClient
+	response string
+	request  *http.Request
+}
+
+func (c *TableObjectsClient) RESTClient() (rest.Interface, error) {
+	return &fake.RESTClient{
+		NegotiatedSerializer: scheme.Codecs.WithoutConversion(),
+		Client: fake.CreateHTTPClient(func(req *http.Request) (*http.Response, error) {
+			c.request = req
+			return &http.Response{
+				StatusCode: http.StatusOK,
+				Header:     http.Header{"Content-Type": []string{"application/json"}},
+				Body:       io.NopCloser(bytes.NewBufferString(c.response)),
+			}, nil
+		}),
+	}, nil
+}
+
+var helmReleaseResources = []*metav1.APIResourceList{
+	{
+		GroupVersion: "helm.toolkit.fluxcd.io/v2",
+		APIResources: []metav1.APIResource{{Kind: "HelmRelease", Name: "helmreleases", Namespaced: true, ShortNames: []string{"hr"}}},
+	},
+}
+
+func TestListTool_Table(t *testing.T) {
+	client := &TableObjectsClient{
+		FakeObjectsClient: FakeObjectsClient{resources: helmReleaseResources},
+		response:          helmReleaseTable,
+	}
+	l := NewListTool(NewFakeMultiClusterClient(client), DefaultOptions())
+
+	result, err := l.Handler(context.Background(), mcp.CallToolRequest{Params: mcp.CallToolParams{Arguments: map[string]any{
+		"kind":   "hr",
+		"output": "table",
+		"limit":  float64(2),
+	}}})
+	assert.NoError(t, err)
+	assert.Equal(t, "/apis/helm.toolkit.fluxcd.io/v2/helmreleases", client.request.URL.Path)
+	assert.Equal(t, "2", client.request.URL.Query().Get("limit"))
+	assert.Equal(t, "Metadata", client.request.URL.Query().Get("includeObject"))
+	assert.Equal(t, tableAcceptHeader, client.request.Header.Get("Accept"))
+	assert.Equal(t, `NAMESPACE     NAME      AGE   READY   STATUS                   REVISION
+apps          podinfo   5d    True    Helm install succeeded   12
+kube-system   cilium    30d   False   <none>                   3
+
+continue: next
+remainingItemCount: 5
+`, result.Content[0].(mcp.TextContent).Text)
+}
+
+func TestListTool_TableNamespacePolicy(t *testing.T) {
+	namespaces, err := policy.NewNamespacePolicy([]policy.NamespaceRule{
+		{Contexts: []string{"test-context"}, Denied: []string{"kube-*"}},
+	})
+	assert.NoError(t, err)
+	opts := DefaultOptions()
+	opts.Namespaces = namespaces
+	opts.Resources, err = policy.NewResourcePolicy(nil, []string{"helmreleases.helm.toolkit.fluxcd.io"})
+	assert.NoError(t, err)
+
+	client := &TableObjectsClient{
+		FakeObjectsClient: FakeObjectsClient{resources: helmReleaseResources},
+		response:          helmReleaseTable,
+	}
+	l := NewListTool(NewFakeMultiClusterClient(client), opts)
+
+	result, err := l.Handler(context.Background(), mcp.CallToolRequest{Params: mcp.CallToolParams{Arguments: map[string]any{
+		"kind":      "HelmRelease",
+		"namespace": "apps",
+		"output":    "table",
+	}}})
+	assert.NoError(t, err)
+	assert.Equal(t, "/apis/helm.toolkit.fluxcd.io/v2/namespaces/apps/helmreleases", client.request.URL.Path)
+	assert.Equal(t, "NAME      AGE\npodinfo   5d\n\ncontinue: next\nremainingItemCount: 5\n", result.Content[0].(mcp.TextContent).Text)
+
+	// Across namespaces blocked rows are removed and the server's remaining count is not reported
+	result, err = l.Handler(context.Background(), mcp.CallToolRequest{Params: mcp.CallToolParams{Arguments: map[string]any{
+		"kind":   "HelmRelease",
+		"output": "table",
+	}}})
+	assert.NoError(t, err)
+	assert.Equal(t, "NAMESPACE   NAME      AGE\napps        podinfo   5d\n\ncontinue: next\n", result.Content[0].(mcp.TextContent).Text)
+}
+
+func TestListTool_TableNotSupported(t *testing.T) {
+	client := &TableObjectsClient{
+		FakeObjectsClient: FakeObjectsClient{resources: helmReleaseResources},
+		response:          `{"kind": "HelmReleaseList", "apiVersion": "helm.toolkit.fluxcd.io/v2", "items": []}`,
+	}
+	l := NewListTool(NewFakeMultiClusterClient(client), DefaultOptions())
+
+	_, err := l.Handler(context.Background(), mcp.CallToolRequest{Params: mcp.CallToolParams{Arguments: map[string]any{
+		"kind":   "HelmRelease",
+		"output": "table",
+	}}})
+	assert.Error(t, err)
+	assert.Contains(t, err.Error(), "did not return a table")
+}
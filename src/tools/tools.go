@@ -1,19 +1,42 @@
 package tools
 
 import (
+	"fmt"
+	"slices"
+
 	"github.com/mark3labs/mcp-go/server"
 )
 
-// RegisterTools は MCPServer に対してツールをまとめて登録します
-func RegisterTools(s *server.MCPServer, multiClient MultiClusterClientInterface) {
+// RegisterTools は MCPServer に対して有効なツールをまとめて登録します
+func RegisterTools(s *server.MCPServer, multiClient MultiClusterClientInterface, opts Options) error {
 	tools := []Tools{
-		NewListTool(multiClient),
-		NewLogTool(multiClient),
-		NewDescribeTool(multiClient),
-		NewListEventsTool(multiClient),
-		NewListContextsTool(multiClient),
+		NewListTool(multiClient, opts),
+		NewLogTool(multiClient, opts),
+		NewDescribeTool(multiClient, opts),
+		NewListEventsTool(multiClient, opts),
+		NewListContextsTool(multiClient, opts),
+		NewCompareTool(multiClient, opts),
+	}
+
+	names := make([]string, 0, len(tools))
+	for _, t := range tools {
+		names = append(names, t.Tool().Name)
+	}
+	for _, name := range append(slices.Clone(opts.EnabledTools), opts.DisabledTools...) {
+		if !slices.Contains(names, name) {
+			return fmt.Errorf("unknown tool '%s': must be one of %v", name, names)
+		}
 	}
+
 	for _, t := range tools {
+		name := t.Tool().Name
+		if len(opts.EnabledTools) > 0 && !slices.Contains(opts.EnabledTools, name) {
+			continue
+		}
+		if slices.Contains(opts.DisabledTools, name) {
+			continue
+		}
 		s.AddTool(t.Tool(), t.Handler)
 	}
+	return nil
 }
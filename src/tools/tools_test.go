@@ -0,0 +1,77 @@
+package tools
+
+import (
+	"context"
+	"encoding/json"
+	"testing"
+
+	"github.com/mark3labs/mcp-go/mcp"
+	"github.com/mark3labs/mcp-go/server"
+	"github.com/stretchr/testify/assert"
+)
+
+func registeredToolNames(t *testing.T, s *server.MCPServer) []string {
+	t.Helper()
+	response := s.HandleMessage(context.Background(), json.RawMessage(`{"jsonrpc":"2.0","id":1,"method":"tools/list"}`))
+	resp, ok := response.(mcp.JSONRPCResponse)
+	if !ok {
+		t.Fatalf("unexpected response %#v", response)
+	}
+	result, ok := resp.Result.(mcp.ListToolsResult)
+	if !ok {
+		t.Fatalf("unexpected result %#v", resp.Result)
+	}
+	var names []string
+	for _, tool := range result.Tools {
+		names = append(names, tool.Name)
+	}
+	return names
+}
+
+func TestRegisterTools(t *testing.T) {
+	testCases := []struct {
+		name          string
+		enabledTools  []string
+		disabledTools []string
+		expected      []string
+		expectedErr   bool
+	}{
+		{
+			name:     "AllToolsByDefault",
+			expected: []string{"compare_resources", "describe_resource", "get_pod_logs", "list_contexts", "list_events", "list_resources"},
+		},
+		{
+			name:         "OnlyEnabledTools",
+			enabledTools: []string{"list_resources", "list_contexts"},
+			expected:     []string{"list_contexts", "list_resources"},
+		},
+		{
+			name:          "DisabledTools",
+			disabledTools: []string{"get_pod_logs"},
+			expected:      []string{"compare_resources", "describe_resource", "list_contexts", "list_events", "list_resources"},
+		},
+		{
+			name:         "UnknownTool",
+			enabledTools: []string{"delete_resource"},
+			expectedErr:  true,
+		},
+	}
+
+	for _, tc := range testCases {
+		t.Run(tc.name, func(t *testing.T) {
+			s := server.NewMCPServer("test", "0.0.0", server.WithToolCapabilities(false))
+			opts := DefaultOptions()
+			opts.EnabledTools = tc.enabledTools
+			opts.DisabledTools = tc.disabledTools
+
+			err := RegisterTools(s, NewFakeMultiClusterClient(FakeKubernetesClient{}), opts)
+
+			if tc.expectedErr {
+				assert.Error(t, err)
+				return
+			}
+			assert.NoError(t, err)
+			assert.Equal(t, tc.expected, registeredToolNames(t, s))
+		})
+	}
+}
@@ -0,0 +1,166 @@
+package transport
+
+import (
+	"context"
+	"crypto/tls"
+	"errors"
+	"fmt"
+	"net/http"
+	"path"
+	"strings"
+	"time"
+
+	"github.com/kkb0318/kubernetes-mcp/src/auth"
+	"github.com/mark3labs/mcp-go/server"
+)
+
+// Supported transport names.
+const (
+	Stdio          = "stdio"
+	SSE            = "sse"
+	StreamableHTTP = "http"
+)
+
+// shutdownTimeout bounds how long in-flight HTTP requests may take to finish on shutdown.
+const shutdownTimeout = 10 * time.Second
+
+// Options configures how the MCP server is exposed to clients.
+type Options struct {
+	// Transport is one of "stdio", "sse" or "http" (streamable HTTP).
+	Transport string
+	// Address is the listen address for the network transports, e.g. ":8080".
+	Address string
+	// BasePath is the URL prefix the MCP endpoints are mounted under, e.g. "/kubernetes".
+	BasePath string
+	// TLSCertFile and TLSKeyFile enable HTTPS when both are set.
+	TLSCertFile string
+	TLSKeyFile  string
+	// ClientCAFile enables verification of TLS client certificates against the given CA bundle.
+	ClientCAFile string
+	// Authenticator, when set, must accept every request before it reaches the MCP endpoints.
+	Authenticator auth.Authenticator
+}
+
+// Validate checks that the options describe a usable transport.
+func (o Options) Validate() error {
+	switch o.Transport {
+	case Stdio:
+		return nil
+	case SSE, StreamableHTTP:
+	default:
+		return fmt.Errorf("unsupported transport '%s': must be one of %s, %s, %s", o.Transport, Stdio, SSE, StreamableHTTP)
+	}
+
+	if o.Address == "" {
+		return errors.New("listen address must be provided for network transports")
+	}
+	if (o.TLSCertFile == "") != (o.TLSKeyFile == "") {
+		return errors.New("both TLS certificate and key files must be provided to enable TLS")
+	}
+	if o.ClientCAFile != "" && o.TLSCertFile == "" {
+		return errors.New("client certificate verification requires TLS to be enabled")
+	}
+	return nil
+}
+
+// Serve runs the MCP server on the configured transport until ctx is cancelled or the transport fails.
+func Serve(ctx context.Context, s *server.MCPServer, opts Options) error {
+	if err := opts.Validate(); err != nil {
+		return err
+	}
+
+	if opts.Transport == Stdio {
+		return server.ServeStdio(s)
+	}
+
+	handler, err := NewHandler(s, opts)
+	if err != nil {
+		return err
+	}
+
+	srv := &http.Server{
+		Addr:              opts.Address,
+		Handler:           handler,
+		ReadHeaderTimeout: 10 * time.Second,
+	}
+	if opts.ClientCAFile != "" {
+		clientCAs, err := auth.LoadClientCAs(opts.ClientCAFile)
+		if err != nil {
+			return err
+		}
+		// Bearer tokens remain usable alongside certificates, so a certificate is only verified if presented.
+		srv.TLSConfig = &tls.Config{
+			ClientCAs:  clientCAs,
+			ClientAuth: tls.VerifyClientCertIfGiven,
+			MinVersion: tls.VersionTLS12,
+		}
+	}
+
+	errCh := make(chan error, 1)
+	go func() {
+		if opts.TLSCertFile != "" {
+			errCh <- srv.ListenAndServeTLS(opts.TLSCertFile, opts.TLSKeyFile)
+		} else {
+			errCh <- srv.ListenAndServe()
+		}
+	}()
+
+	select {
+	case err := <-errCh:
+		if errors.Is(err, http.ErrServerClosed) {
+			return nil
+		}
+		return fmt.Errorf("failed to serve %s transport: %w", opts.Transport, err)
+	case <-ctx.Done():
+		shutdownCtx, cancel := context.WithTimeout(context.Background(), shutdownTimeout)
+		defer cancel()
+		return srv.Shutdown(shutdownCtx)
+	}
+}
+
+// NewHandler builds the HTTP handler serving the MCP endpoints for the network transports.
+// A "/healthz" endpoint is always served so the server can be probed when deployed in-cluster;
+// it is the only endpoint that bypasses the configured Authenticator.
+func NewHandler(s *server.MCPServer, opts Options) (http.Handler, error) {
+	basePath := normalizeBasePath(opts.BasePath)
+	mux := http.NewServeMux()
+	mux.HandleFunc("/healthz", func(w http.ResponseWriter, r *http.Request) {
+		w.WriteHeader(http.StatusOK)
+		_, _ = w.Write([]byte("ok"))
+	})
+
+	switch opts.Transport {
+	case SSE:
+		// Clients concatenate the message endpoint with the URL they connected to,
+		// which keeps the server usable behind proxies and ingress controllers.
+		sseServer := server.NewSSEServer(s,
+			server.WithStaticBasePath(basePath),
+			server.WithUseFullURLForMessageEndpoint(false),
+		)
+		mux.Handle(sseServer.CompleteSsePath(), opts.authenticate(sseServer))
+		mux.Handle(sseServer.CompleteMessagePath(), opts.authenticate(sseServer))
+	case StreamableHTTP:
+		mux.Handle(path.Join(basePath, "/mcp"), opts.authenticate(server.NewStreamableHTTPServer(s)))
+	default:
+		return nil, fmt.Errorf("transport '%s' is not served over HTTP", opts.Transport)
+	}
+
+	return mux, nil
+}
+
+// authenticate wraps h with the configured Authenticator, if any.
+func (o Options) authenticate(h http.Handler) http.Handler {
+	if o.Authenticator == nil {
+		return h
+	}
+	return auth.Middleware(o.Authenticator, h)
+}
+
+// normalizeBasePath returns the base path with a leading slash and without a trailing one.
+func normalizeBasePath(basePath string) string {
+	trimmed := strings.Trim(basePath, "/")
+	if trimmed == "" {
+		return "/"
+	}
+	return "/" + trimmed
+}
@@ -0,0 +1,129 @@
+package transport
+
+import (
+	"net/http"
+	"net/http/httptest"
+	"strings"
+	"testing"
+
+	"github.com/kkb0318/kubernetes-mcp/src/auth"
+	"github.com/mark3labs/mcp-go/server"
+	"github.com/stretchr/testify/assert"
+)
+
+func TestOptions_Validate(t *testing.T) {
+	testCases := []struct {
+		name        string
+		opts        Options
+		expectedErr bool
+	}{
+		{
+			name: "Stdio",
+			opts: Options{Transport: Stdio},
+		},
+		{
+			name: "StreamableHTTP",
+			opts: Options{Transport: StreamableHTTP, Address: ":8080"},
+		},
+		{
+			name: "SSEWithTLS",
+			opts: Options{Transport: SSE, Address: ":8443", TLSCertFile: "tls.crt", TLSKeyFile: "tls.key"},
+		},
+		{
+			name:        "UnknownTransport",
+			opts:        Options{Transport: "websocket", Address: ":8080"},
+			expectedErr: true,
+		},
+		{
+			name:        "MissingAddress",
+			opts:        Options{Transport: StreamableHTTP},
+			expectedErr: true,
+		},
+		{
+			name:        "ClientCAWithoutTLS",
+			opts:        Options{Transport: SSE, Address: ":8080", ClientCAFile: "ca.crt"},
+			expectedErr: true,
+		},
+		{
+			name:        "CertWithoutKey",
+			opts:        Options{Transport: SSE, Address: ":8443", TLSCertFile: "tls.crt"},
+			expectedErr: true,
+		},
+	}
+
+	for _, tc := range testCases {
+		t.Run(tc.name, func(t *testing.T) {
+			err := tc.opts.Validate()
+			if tc.expectedErr {
+				assert.Error(t, err)
+			} else {
+				assert.NoError(t, err)
+			}
+		})
+	}
+}
+
+func TestNewHandler_StreamableHTTP(t *testing.T) {
+	s := server.NewMCPServer("test", "0.0.0", server.WithToolCapabilities(false))
+	handler, err := NewHandler(s, Options{Transport: StreamableHTTP, Address: ":0", BasePath: "/kubernetes/"})
+	assert.NoError(t, err)
+
+	body := `{"jsonrpc":"2.0","id":1,"method":"initialize","params":{"protocolVersion":"2025-03-26","capabilities":{},"clientInfo":{"name":"test","version":"0.0.0"}}}`
+	req := httptest.NewRequest(http.MethodPost, "/kubernetes/mcp", strings.NewReader(body))
+	req.Header.Set("Content-Type", "application/json")
+	rec := httptest.NewRecorder()
+	handler.ServeHTTP(rec, req)
+
+	assert.Equal(t, http.StatusOK, rec.Code)
+	assert.Contains(t, rec.Body.String(), `"serverInfo"`)
+
+	rec = httptest.NewRecorder()
+	handler.ServeHTTP(rec, httptest.NewRequest(http.MethodPost, "/mcp", strings.NewReader(body)))
+	assert.Equal(t, http.StatusNotFound, rec.Code)
+}
+
+func TestNewHandler_Healthz(t *testing.T) {
+	s := server.NewMCPServer("test", "0.0.0", server.WithToolCapabilities(false))
+	for _, transport := range []string{SSE, StreamableHTTP} {
+		t.Run(transport, func(t *testing.T) {
+			handler, err := NewHandler(s, Options{Transport: transport, Address: ":0"})
+			assert.NoError(t, err)
+
+			rec := httptest.NewRecorder()
+			handler.ServeHTTP(rec, httptest.NewRequest(http.MethodGet, "/healthz", nil))
+			assert.Equal(t, http.StatusOK, rec.Code)
+		})
+	}
+}
+
+func TestNewHandler_Authenticator(t *testing.T) {
+	s := server.NewMCPServer("test", "0.0.0", server.WithToolCapabilities(false))
+	handler, err := NewHandler(s, Options{
+		Transport:     StreamableHTTP,
+		Address:       ":0",
+		Authenticator: auth.NewStaticTokenAuthenticator(map[string]*auth.UserInfo{"good": {Name: "alice"}}),
+	})
+	assert.NoError(t, err)
+
+	body := `{"jsonrpc":"2.0","id":1,"method":"initialize","params":{"protocolVersion":"2025-03-26","capabilities":{},"clientInfo":{"name":"test","version":"0.0.0"}}}`
+	for token, expectedCode := range map[string]int{"": http.StatusUnauthorized, "bad": http.StatusUnauthorized, "good": http.StatusOK} {
+		req := httptest.NewRequest(http.MethodPost, "/mcp", strings.NewReader(body))
+		req.Header.Set("Content-Type", "application/json")
+		if token != "" {
+			req.Header.Set("Authorization", "Bearer "+token)
+		}
+		rec := httptest.NewRecorder()
+		handler.ServeHTTP(rec, req)
+		assert.Equal(t, expectedCode, rec.Code, "token %q", token)
+	}
+
+	rec := httptest.NewRecorder()
+	handler.ServeHTTP(rec, httptest.NewRequest(http.MethodGet, "/healthz", nil))
+	assert.Equal(t, http.StatusOK, rec.Code)
+}
+
+func TestNewHandler_Stdio(t *testing.T) {
+	s := server.NewMCPServer("test", "0.0.0")
+	_, err := NewHandler(s, Options{Transport: Stdio})
+	assert.Error(t, err)
+}
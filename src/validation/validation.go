@@ -77,7 +77,9 @@ func ValidateLabelSelector(selector string) error {
 	return nil
 }
 
-// ValidateKind validates Kubernetes resource kinds
+// ValidateKind validates Kubernetes resource kinds.
+// A kind, plural name or short name may be qualified with its API group as "kind.group"
+// (e.g. "deployments.apps") or given as "group/version/kind" (e.g. "apps/v1/Deployment", "v1/Pod").
 func ValidateKind(kind string) error {
 	if kind == "" {
 		return fmt.Errorf("resource kind cannot be empty")
@@ -90,9 +92,29 @@ func ValidateKind(kind string) error {
 	// Allow both uppercase (Kind) and lowercase (resource names) formats
 	// Must contain only alphanumeric characters and start with letter
 	validKind := regexp.MustCompile(`^[a-zA-Z][a-zA-Z0-9]*$`)
-	if !validKind.MatchString(kind) {
+	name, group, qualified := strings.Cut(kind, ".")
+	if parts := strings.Split(kind, "/"); len(parts) > 1 {
+		if len(parts) > 3 {
+			return fmt.Errorf("invalid resource kind: expected group/version/kind")
+		}
+		name, qualified = parts[len(parts)-1], false
+		validVersion := regexp.MustCompile(`^[a-z0-9]+$`)
+		if !validVersion.MatchString(parts[len(parts)-2]) {
+			return fmt.Errorf("invalid resource kind: invalid version %q", parts[len(parts)-2])
+		}
+		if len(parts) == 3 && !validGroup.MatchString(parts[0]) {
+			return fmt.Errorf("invalid resource kind: invalid API group %q", parts[0])
+		}
+	}
+	if !validKind.MatchString(name) {
 		return fmt.Errorf("invalid resource kind: must start with letter and contain only alphanumeric characters")
 	}
+	if qualified && !validGroup.MatchString(group) {
+		return fmt.Errorf("invalid resource kind: invalid API group %q", group)
+	}
 	
 	return nil
-}
\ No newline at end of file
+}
+
+// validGroup matches API group names, which are DNS subdomains
+var validGroup = regexp.MustCompile(`^[a-z0-9]([-a-z0-9]*[a-z0-9])?(\.[a-z0-9]([-a-z0-9]*[a-z0-9])?)*$`)